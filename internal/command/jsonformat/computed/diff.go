@@ -5,6 +5,7 @@ package computed
 
 import (
 	"github.com/mitchellh/colorstring"
+	"github.com/zclconf/go-cty/cty"
 
 	"github.com/hashicorp/terraform/internal/plans"
 )
@@ -63,9 +64,53 @@ func (diff Diff) WarningsHuman(indent int, opts RenderHumanOpts) []string {
 	return diff.Renderer.WarningsHuman(diff, indent, opts)
 }
 
+// RenderJSON returns a structured, machine-readable representation of the
+// diff tree rooted at this Diff, for consumers that want to process a plan
+// diff programmatically instead of scraping the human-readable text.
+//
+// Unlike RenderHuman, the result carries no color codes and doesn't depend
+// on any RenderHumanOpts, since there's nothing left to configure once the
+// diff is expressed as plain data.
+func (diff Diff) RenderJSON() DiffJSON {
+	return diff.Renderer.RenderJSON(diff)
+}
+
 type DiffRenderer interface {
 	RenderHuman(diff Diff, indent int, opts RenderHumanOpts) string
 	WarningsHuman(diff Diff, indent int, opts RenderHumanOpts) []string
+	RenderJSON(diff Diff) DiffJSON
+}
+
+// DiffJSON is the structured equivalent of RenderHuman, mirroring the same
+// {action, before, after, children} shape regardless of which renderer
+// produced it.
+//
+// Before and After hold the raw values of a primitive change. For an unknown
+// change, Before holds the DiffJSON of the prior value (if any), and for a
+// type change Before and After hold the DiffJSON of each side. Sensitive
+// changes leave both empty. Composite changes (objects, blocks, lists, maps
+// and sets) instead populate Children, either as a map keyed by attribute or
+// block name, or as an ordered slice for list-like collections.
+type DiffJSON struct {
+	Action string `json:"action"`
+
+	// Replace is true if this change should add the `# forces replacement`
+	// suffix when rendered as human output, mirroring the Diff.Replace
+	// field that produced it.
+	Replace bool `json:"replace,omitempty"`
+
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after,omitempty"`
+
+	Children interface{} `json:"children,omitempty"`
+
+	// Unknown is true if this change represents a value that won't be known
+	// until apply.
+	Unknown bool `json:"unknown,omitempty"`
+
+	// Sensitive is true if this change represents a value that is marked as
+	// sensitive before or after the change, so Before and After are withheld.
+	Sensitive bool `json:"sensitive,omitempty"`
 }
 
 // RenderHumanOpts contains options that can control how the human render
@@ -102,6 +147,81 @@ type RenderHumanOpts struct {
 	// HideDiffActionSymbols tells the renderer not to show the '+'/'-' symbols
 	// and to skip the places where the symbols would result in an offset.
 	HideDiffActionSymbols bool
+
+	// MaxDepth limits how many levels of nested Object, Block, Map, List, and
+	// Set changes are expanded before the renderer collapses the remainder
+	// into a single `{ ... N nested changes }` summary line. N is the number
+	// of direct children of the collapsed value (for a block, its attributes
+	// plus its nested block types), whether or not each has changed. A value of 0
+	// (the default) means unlimited, preserving the historical behaviour of
+	// always expanding every level.
+	MaxDepth int
+
+	// MapChangedKeysOnly tells the Map renderer to skip rendering its
+	// elements (changed or not) entirely, and instead render a single terse
+	// header such as `{ 3 of 5000 keys changed }`. This is useful for maps
+	// with so many keys that even hiding the unchanged ones still produces
+	// an unwieldy diff. Default off.
+	MapChangedKeysOnly bool
+
+	// StableSetOrder tells the Set renderer to sort its elements into a
+	// deterministic order before rendering, instead of using the order the
+	// elements were supplied in. Set elements have no inherent ordering, so
+	// without this the display order can vary from one plan to the next and
+	// produce noisy diffs. Default off, to preserve historical behaviour.
+	StableSetOrder bool
+
+	// CollapseComputedIDs tells the Block renderer to collapse multiple
+	// `id`-like attributes (named "id" or ending in "_id") that are all
+	// becoming known after apply into a single summary line, instead of
+	// printing a separate `(known after apply)` line for each one. This
+	// keeps plans for resources with many computed sub-resource IDs
+	// readable. Default off.
+	CollapseComputedIDs bool
+
+	// ShowSensitiveHashes tells the Sensitive renderer to print a SHA-256
+	// hash of the JSON representation of a sensitive value's underlying
+	// change (its action and its before and after values) alongside
+	// the usual `(sensitive value)` text, so that two plans can be compared
+	// for whether a sensitive value actually changed without revealing what
+	// it changed to. Default off, since even a hash reveals more about a
+	// sensitive value than some configurations are comfortable with.
+	ShowSensitiveHashes bool
+
+	// SensitiveHashSalt is mixed into the hash that ShowSensitiveHashes
+	// prints, so that the hash can't be reversed for a low-entropy
+	// sensitive value (a short password, a small token) via a dictionary
+	// or rainbow-table attack against an unsalted SHA-256. Callers that
+	// enable ShowSensitiveHashes should set this to a value that's kept
+	// out of the rendered output, such as a random value generated once
+	// per render. Default nil, which hashes the value unsalted.
+	SensitiveHashSalt []byte
+
+	// AlignSiblingObjects tells the Block renderer to compute a single
+	// shared `=` alignment width across all of its directly nested Object
+	// attributes, instead of letting each one align its own attributes
+	// independently. Without this, two sibling objects with differently
+	// sized attribute names line up their `=` signs separately and look
+	// ragged next to each other. Default off to preserve historical
+	// rendering.
+	AlignSiblingObjects bool
+
+	// ObjectAlignmentWidth is set by the Block renderer when
+	// AlignSiblingObjects is enabled, to tell a nested Object renderer the
+	// minimum `=` alignment width it should use instead of (or as a floor
+	// for) the width it would otherwise compute from its own attributes.
+	// It has no effect on its own; callers should not set it directly.
+	ObjectAlignmentWidth int
+
+	// ValueFormatters lets a caller override how the Primitive renderer
+	// formats a non-null value of a given type, for types where the raw
+	// value (a number of seconds, a count of bytes) is technically correct
+	// but less readable than a formatted equivalent. The Primitive renderer
+	// consults this before falling back to its default formatting for that
+	// type. Only number and bool values are passed to these formatters;
+	// string values always use the default string rendering. Default nil,
+	// which preserves the default formatting for every type.
+	ValueFormatters map[cty.Type]func(cty.Value) string
 }
 
 // NewRenderHumanOpts creates a new RenderHumanOpts struct with the required
@@ -121,6 +241,19 @@ func (opts RenderHumanOpts) Clone() RenderHumanOpts {
 		OverrideNullSuffix:    opts.OverrideNullSuffix,
 		ShowUnchangedChildren: opts.ShowUnchangedChildren,
 		HideDiffActionSymbols: opts.HideDiffActionSymbols,
+		MaxDepth:              opts.MaxDepth,
+		MapChangedKeysOnly:    opts.MapChangedKeysOnly,
+		StableSetOrder:        opts.StableSetOrder,
+		CollapseComputedIDs:   opts.CollapseComputedIDs,
+		ShowSensitiveHashes:   opts.ShowSensitiveHashes,
+		SensitiveHashSalt:     opts.SensitiveHashSalt,
+		AlignSiblingObjects:   opts.AlignSiblingObjects,
+		ValueFormatters:       opts.ValueFormatters,
+
+		// ObjectAlignmentWidth doesn't cascade either: the Block renderer
+		// hands it only to its directly nested Object attributes, so objects
+		// nested further down align their own attributes independently.
+		ObjectAlignmentWidth: 0,
 
 		// ForceForcesReplacement and ForbidForcesReplacement are special cases
 		// in that they don't cascade. So each diff should decide independently
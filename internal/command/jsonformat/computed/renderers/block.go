@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/hashicorp/terraform/internal/command/jsonformat/computed"
 
@@ -32,6 +33,12 @@ func importantAttribute(attr string) bool {
 	return false
 }
 
+// isIDAttribute returns true for attribute names that conventionally hold an
+// identifier: "id" itself, or any name ending in "_id".
+func isIDAttribute(attr string) bool {
+	return attr == "id" || strings.HasSuffix(attr, "_id")
+}
+
 func Block(attributes map[string]computed.Diff, blocks Blocks) computed.DiffRenderer {
 	return &blockRenderer{
 		attributes: attributes,
@@ -51,6 +58,10 @@ func (renderer blockRenderer) RenderHuman(diff computed.Diff, indent int, opts c
 		return fmt.Sprintf("{}%s", forcesReplacement(diff.Replace, opts))
 	}
 
+	if opts.MaxDepth > 0 && indent >= opts.MaxDepth {
+		return collapsedSummary("{", "}", diff, len(renderer.attributes)+len(renderer.blocks.GetAllKeys()), opts)
+	}
+
 	unchangedAttributes := 0
 	unchangedBlocks := 0
 
@@ -67,14 +78,52 @@ func (renderer blockRenderer) RenderHuman(diff computed.Diff, indent int, opts c
 	}
 	sort.Strings(attributeKeys)
 
+	collapsedComputedIDs := make(map[string]bool)
+	if opts.CollapseComputedIDs {
+		var idKeys []string
+		for _, key := range attributeKeys {
+			if !isIDAttribute(key) {
+				continue
+			}
+			if _, ok := renderer.attributes[key].Renderer.(*unknownRenderer); ok {
+				idKeys = append(idKeys, key)
+			}
+		}
+		if len(idKeys) > 1 {
+			for _, key := range idKeys {
+				collapsedComputedIDs[key] = true
+			}
+		}
+	}
+
 	importantAttributeOpts := opts.Clone()
 	importantAttributeOpts.ShowUnchangedChildren = true
 
 	attributeOpts := opts.Clone()
 
+	if opts.AlignSiblingObjects {
+		sharedObjectAlignment := 0
+		for _, key := range attributeKeys {
+			objectRenderer, ok := renderer.attributes[key].Renderer.(*objectRenderer)
+			if !ok {
+				continue
+			}
+			for attributeKey := range objectRenderer.attributes {
+				if keyLen := len(EnsureValidAttributeName(attributeKey)); keyLen > sharedObjectAlignment {
+					sharedObjectAlignment = keyLen
+				}
+			}
+		}
+		importantAttributeOpts.ObjectAlignmentWidth = sharedObjectAlignment
+		attributeOpts.ObjectAlignmentWidth = sharedObjectAlignment
+	}
+
 	var buf bytes.Buffer
 	buf.WriteString(fmt.Sprintf("{%s\n", forcesReplacement(diff.Replace, opts)))
 	for _, key := range attributeKeys {
+		if collapsedComputedIDs[key] {
+			continue
+		}
 		attribute := renderer.attributes[key]
 		if importantAttribute(key) {
 
@@ -100,6 +149,15 @@ func (renderer blockRenderer) RenderHuman(diff computed.Diff, indent int, opts c
 		buf.WriteString(fmt.Sprintf("%s%s%s\n", formatIndent(indent+1), writeDiffActionSymbol(plans.NoOp, opts), unchanged("attribute", unchangedAttributes, opts)))
 	}
 
+	if len(collapsedComputedIDs) > 0 {
+		var ids []string
+		for key := range collapsedComputedIDs {
+			ids = append(ids, key)
+		}
+		sort.Strings(ids)
+		buf.WriteString(fmt.Sprintf("%s%s%s\n", formatIndent(indent+1), writeDiffActionSymbol(plans.Update, opts), computedIDsSummary(ids, opts)))
+	}
+
 	blockKeys := renderer.blocks.GetAllKeys()
 	for _, key := range blockKeys {
 
@@ -195,3 +253,41 @@ func (renderer blockRenderer) RenderHuman(diff computed.Diff, indent int, opts c
 	buf.WriteString(fmt.Sprintf("%s%s}", formatIndent(indent), writeDiffActionSymbol(plans.NoOp, opts)))
 	return buf.String()
 }
+
+func (renderer blockRenderer) RenderJSON(diff computed.Diff) computed.DiffJSON {
+	children := make(map[string]computed.DiffJSON, len(renderer.attributes))
+	for key, attribute := range renderer.attributes {
+		children[key] = attribute.RenderJSON()
+	}
+
+	for _, key := range renderer.blocks.GetAllKeys() {
+		switch {
+		case renderer.blocks.IsSingleBlock(key):
+			children[key] = renderer.blocks.SingleBlocks[key].RenderJSON()
+		case renderer.blocks.IsMapBlock(key):
+			blockChildren := make(map[string]computed.DiffJSON, len(renderer.blocks.MapBlocks[key]))
+			for innerKey, block := range renderer.blocks.MapBlocks[key] {
+				blockChildren[innerKey] = block.RenderJSON()
+			}
+			children[key] = computed.DiffJSON{Action: diff.Action.String(), Children: blockChildren, Replace: renderer.blocks.ReplaceBlocks[key]}
+		case renderer.blocks.IsSetBlock(key):
+			blockChildren := make([]computed.DiffJSON, 0, len(renderer.blocks.SetBlocks[key]))
+			for _, block := range renderer.blocks.SetBlocks[key] {
+				blockChildren = append(blockChildren, block.RenderJSON())
+			}
+			children[key] = computed.DiffJSON{Action: diff.Action.String(), Children: blockChildren, Replace: renderer.blocks.ReplaceBlocks[key]}
+		case renderer.blocks.IsListBlock(key):
+			blockChildren := make([]computed.DiffJSON, 0, len(renderer.blocks.ListBlocks[key]))
+			for _, block := range renderer.blocks.ListBlocks[key] {
+				blockChildren = append(blockChildren, block.RenderJSON())
+			}
+			children[key] = computed.DiffJSON{Action: diff.Action.String(), Children: blockChildren, Replace: renderer.blocks.ReplaceBlocks[key]}
+		}
+	}
+
+	return computed.DiffJSON{
+		Action:   diff.Action.String(),
+		Replace:  diff.Replace,
+		Children: children,
+	}
+}
@@ -38,6 +38,10 @@ func (renderer listRenderer) RenderHuman(diff computed.Diff, indent int, opts co
 		return fmt.Sprintf("[]%s%s", nullSuffix(diff.Action, opts), forcesReplacement(diff.Replace, opts))
 	}
 
+	if opts.MaxDepth > 0 && indent >= opts.MaxDepth {
+		return collapsedSummary("[", "]", diff, len(renderer.elements), opts)
+	}
+
 	elementOpts := opts.Clone()
 	elementOpts.OverrideNullSuffix = true
 
@@ -125,3 +129,15 @@ func (renderer listRenderer) RenderHuman(diff computed.Diff, indent int, opts co
 	buf.WriteString(fmt.Sprintf("%s%s]%s", formatIndent(indent), writeDiffActionSymbol(plans.NoOp, opts), nullSuffix(diff.Action, opts)))
 	return buf.String()
 }
+
+func (renderer listRenderer) RenderJSON(diff computed.Diff) computed.DiffJSON {
+	children := make([]computed.DiffJSON, 0, len(renderer.elements))
+	for _, element := range renderer.elements {
+		children = append(children, element.RenderJSON())
+	}
+	return computed.DiffJSON{
+		Action:   diff.Action.String(),
+		Replace:  diff.Replace,
+		Children: children,
+	}
+}
@@ -48,6 +48,10 @@ func (renderer mapRenderer) RenderHuman(diff computed.Diff, indent int, opts com
 		return fmt.Sprintf("{}%s%s", nullSuffix(diff.Action, opts), forcesReplacement(forcesReplacementSelf, opts))
 	}
 
+	if opts.MaxDepth > 0 && indent >= opts.MaxDepth {
+		return collapsedSummary("{", "}", diff, len(renderer.elements), opts)
+	}
+
 	// Sort the map elements by key, so we have a deterministic ordering in
 	// the output.
 	var keys []string
@@ -67,6 +71,16 @@ func (renderer mapRenderer) RenderHuman(diff computed.Diff, indent int, opts com
 	}
 	sort.Strings(keys)
 
+	if opts.MapChangedKeysOnly {
+		changed := 0
+		for _, element := range renderer.elements {
+			if element.Action != plans.NoOp {
+				changed++
+			}
+		}
+		return fmt.Sprintf("{ %d of %d keys changed }%s%s", changed, len(renderer.elements), nullSuffix(diff.Action, opts), forcesReplacement(forcesReplacementSelf, opts))
+	}
+
 	unchangedElements := 0
 
 	elementOpts := opts.Clone()
@@ -108,3 +122,15 @@ func (renderer mapRenderer) RenderHuman(diff computed.Diff, indent int, opts com
 	buf.WriteString(fmt.Sprintf("%s%s}%s", formatIndent(indent), writeDiffActionSymbol(plans.NoOp, opts), nullSuffix(diff.Action, opts)))
 	return buf.String()
 }
+
+func (renderer mapRenderer) RenderJSON(diff computed.Diff) computed.DiffJSON {
+	children := make(map[string]computed.DiffJSON, len(renderer.elements))
+	for key, element := range renderer.elements {
+		children[key] = element.RenderJSON()
+	}
+	return computed.DiffJSON{
+		Action:   diff.Action.String(),
+		Replace:  diff.Replace,
+		Children: children,
+	}
+}
@@ -40,6 +40,10 @@ func (renderer objectRenderer) RenderHuman(diff computed.Diff, indent int, opts
 		return fmt.Sprintf("{}%s%s", nullSuffix(diff.Action, opts), forcesReplacement(diff.Replace, opts))
 	}
 
+	if opts.MaxDepth > 0 && indent >= opts.MaxDepth {
+		return collapsedSummary("{", "}", diff, len(renderer.attributes), opts)
+	}
+
 	attributeOpts := opts.Clone()
 	attributeOpts.OverrideNullSuffix = renderer.overrideNullSuffix
 
@@ -47,7 +51,7 @@ func (renderer objectRenderer) RenderHuman(diff computed.Diff, indent int, opts
 	// which we will display them. The second is a mapping to their safely
 	// escaped equivalent.
 
-	maximumKeyLen := 0
+	maximumKeyLen := opts.ObjectAlignmentWidth
 	var keys []string
 	escapedKeys := make(map[string]string)
 	for key := range renderer.attributes {
@@ -96,3 +100,15 @@ func (renderer objectRenderer) RenderHuman(diff computed.Diff, indent int, opts
 	buf.WriteString(fmt.Sprintf("%s%s}%s", formatIndent(indent), writeDiffActionSymbol(plans.NoOp, opts), nullSuffix(diff.Action, opts)))
 	return buf.String()
 }
+
+func (renderer objectRenderer) RenderJSON(diff computed.Diff) computed.DiffJSON {
+	children := make(map[string]computed.DiffJSON, len(renderer.attributes))
+	for key, attribute := range renderer.attributes {
+		children[key] = attribute.RenderJSON()
+	}
+	return computed.DiffJSON{
+		Action:   diff.Action.String(),
+		Replace:  diff.Replace,
+		Children: children,
+	}
+}
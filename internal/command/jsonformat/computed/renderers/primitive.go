@@ -55,11 +55,24 @@ func (renderer primitiveRenderer) RenderHuman(diff computed.Diff, indent int, op
 	}
 }
 
+func (renderer primitiveRenderer) RenderJSON(diff computed.Diff) computed.DiffJSON {
+	return computed.DiffJSON{
+		Action:  diff.Action.String(),
+		Replace: diff.Replace,
+		Before:  renderer.before,
+		After:   renderer.after,
+	}
+}
+
 func renderPrimitiveValue(value interface{}, t cty.Type, opts computed.RenderHumanOpts) string {
 	if value == nil {
 		return opts.Colorize.Color("[dark_gray]null[reset]")
 	}
 
+	if formatter, ok := opts.ValueFormatters[t]; ok {
+		return formatter(ctyValueForFormatter(value, t))
+	}
+
 	switch {
 	case t == cty.Bool:
 		if value.(bool) {
@@ -74,6 +87,27 @@ func renderPrimitiveValue(value interface{}, t cty.Type, opts computed.RenderHum
 	}
 }
 
+// ctyValueForFormatter converts a decoded JSON primitive value back into the
+// equivalent cty.Value, so that a caller-supplied RenderHumanOpts.ValueFormatters
+// function can consume it without needing to know about our JSON decoding.
+func ctyValueForFormatter(value interface{}, t cty.Type) cty.Value {
+	switch {
+	case t == cty.Bool:
+		return cty.BoolVal(value.(bool))
+	case t == cty.Number:
+		number, err := cty.ParseNumberVal(value.(json.Number).String())
+		if err != nil {
+			// The value was already decoded from JSON as a number, so this
+			// should be unreachable; fall back to unknown rather than panic
+			// over a formatting convenience.
+			return cty.UnknownVal(cty.Number)
+		}
+		return number
+	default:
+		panic("unrecognized primitive type: " + t.FriendlyName())
+	}
+}
+
 func (renderer primitiveRenderer) renderStringDiff(diff computed.Diff, indent int, opts computed.RenderHumanOpts) string {
 
 	// We process multiline strings at the end of the switch statement.
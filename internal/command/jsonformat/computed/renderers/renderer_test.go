@@ -5,6 +5,7 @@ package renderers
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -459,6 +460,24 @@ jsonencode(
 			},
 			expected: "0 -> (known after apply) # forces replacement",
 		},
+		"computed_update_object": {
+			diff: computed.Diff{
+				Renderer: Unknown(computed.Diff{
+					Renderer: Object(map[string]computed.Diff{
+						"attribute_one": {
+							Renderer: Primitive("value", nil, cty.String),
+							Action:   plans.Delete,
+						},
+					}),
+					Action: plans.Delete,
+				}),
+				Action: plans.Update,
+			},
+			expected: `
+{
+      - attribute_one = "value"
+    } -> (known after apply)`,
+		},
 		"object_created": {
 			diff: computed.Diff{
 				Renderer: Object(map[string]computed.Diff{}),
@@ -1807,6 +1826,97 @@ jsonencode(
         }
 
         # (1 unchanged block hidden)
+    }`,
+		},
+		"block_collapses_computed_ids": {
+			diff: computed.Diff{
+				Renderer: Block(map[string]computed.Diff{
+					"name": {
+						Renderer: Primitive(nil, "example", cty.String),
+						Action:   plans.Create,
+					},
+					"backend_pool_id": {
+						Renderer: Unknown(computed.Diff{}),
+						Action:   plans.Create,
+					},
+					"probe_id": {
+						Renderer: Unknown(computed.Diff{}),
+						Action:   plans.Create,
+					},
+					"ssl_certificate_id": {
+						Renderer: Unknown(computed.Diff{}),
+						Action:   plans.Create,
+					},
+				}, Blocks{}),
+				Action: plans.Create,
+			},
+			opts: computed.RenderHumanOpts{
+				CollapseComputedIDs: true,
+			},
+			expected: `
+{
+      + name               = "example"
+      ~ # (3 computed ids become known after apply: backend_pool_id, probe_id, ssl_certificate_id)
+    }`,
+		},
+		"block_does_not_collapse_single_computed_id": {
+			diff: computed.Diff{
+				Renderer: Block(map[string]computed.Diff{
+					"name": {
+						Renderer: Primitive(nil, "example", cty.String),
+						Action:   plans.Create,
+					},
+					"probe_id": {
+						Renderer: Unknown(computed.Diff{}),
+						Action:   plans.Create,
+					},
+				}, Blocks{}),
+				Action: plans.Create,
+			},
+			opts: computed.RenderHumanOpts{
+				CollapseComputedIDs: true,
+			},
+			expected: `
+{
+      + name     = "example"
+      + probe_id = (known after apply)
+    }`,
+		},
+		"block_aligns_sibling_objects": {
+			diff: computed.Diff{
+				Renderer: Block(map[string]computed.Diff{
+					"first": {
+						Renderer: Object(map[string]computed.Diff{
+							"a": {
+								Renderer: Primitive("before", "after", cty.String),
+								Action:   plans.Update,
+							},
+						}),
+						Action: plans.Update,
+					},
+					"second": {
+						Renderer: Object(map[string]computed.Diff{
+							"much_longer_key": {
+								Renderer: Primitive("before", "after", cty.String),
+								Action:   plans.Update,
+							},
+						}),
+						Action: plans.Update,
+					},
+				}, Blocks{}),
+				Action: plans.Update,
+			},
+			opts: computed.RenderHumanOpts{
+				AlignSiblingObjects: true,
+			},
+			expected: `
+{
+      ~ first  = {
+          ~ a               = "before" -> "after"
+        }
+      ~ second = {
+          ~ much_longer_key = "before" -> "after"
+        }
     }`,
 		},
 		"clear_populated_block": {
@@ -2212,6 +2322,66 @@ jsonencode(
     ]
 `,
 		},
+		"object_max_depth_collapses_nested_changes": {
+			diff: computed.Diff{
+				Renderer: Object(map[string]computed.Diff{
+					"outer": {
+						Renderer: Object(map[string]computed.Diff{
+							"middle": {
+								Renderer: Object(map[string]computed.Diff{
+									"inner": {
+										Renderer: Primitive("before", "after", cty.String),
+										Action:   plans.Update,
+									},
+								}),
+								Action: plans.Update,
+							},
+						}),
+						Action: plans.Update,
+					},
+				}),
+				Action: plans.Update,
+			},
+			opts: computed.RenderHumanOpts{
+				ShowUnchangedChildren: true,
+				MaxDepth:              2,
+			},
+			expected: `
+{
+      ~ outer = {
+          ~ middle = { ... 1 nested change }
+        }
+    }
+`,
+		},
+		"map_changed_keys_only_disabled": {
+			diff: computed.Diff{
+				Renderer: Map(map[string]computed.Diff{
+					"unchanged_one": {Renderer: Primitive("a", "a", cty.String), Action: plans.NoOp},
+					"changed":       {Renderer: Primitive("before", "after", cty.String), Action: plans.Update},
+				}),
+				Action: plans.Update,
+			},
+			expected: `
+{
+      ~ "changed"       = "before" -> "after"
+        # (1 unchanged element hidden)
+    }
+`,
+		},
+		"map_changed_keys_only_enabled": {
+			diff: computed.Diff{
+				Renderer: Map(map[string]computed.Diff{
+					"unchanged_one": {Renderer: Primitive("a", "a", cty.String), Action: plans.NoOp},
+					"changed":       {Renderer: Primitive("before", "after", cty.String), Action: plans.Update},
+				}),
+				Action: plans.Update,
+			},
+			opts: computed.RenderHumanOpts{
+				MapChangedKeysOnly: true,
+			},
+			expected: "{ 1 of 2 keys changed }",
+		},
 		"json_string_no_symbols": {
 			diff: computed.Diff{
 				Renderer: Primitive("{\"key\":\"value\"}", "{\"key\":\"value\"}", cty.String),
@@ -2229,6 +2399,59 @@ jsonencode(
 )
 `,
 		},
+		"primitive_update_custom_value_formatter": {
+			diff: computed.Diff{
+				Renderer: Primitive(json.Number("1024"), json.Number("2048"), cty.Number),
+				Action:   plans.Update,
+			},
+			opts: computed.RenderHumanOpts{
+				ValueFormatters: map[cty.Type]func(cty.Value) string{
+					cty.Number: func(value cty.Value) string {
+						bf := value.AsBigFloat()
+						bytes, _ := bf.Float64()
+						return fmt.Sprintf("%.0fKiB", bytes/1024)
+					},
+				},
+			},
+			expected: `1KiB -> 2KiB`,
+		},
+		"sensitive_update_hashes_disabled": {
+			diff: computed.Diff{
+				Renderer: Sensitive(computed.Diff{
+					Renderer: Primitive(json.Number("0"), json.Number("1"), cty.Number),
+					Action:   plans.Update,
+				}, true, true),
+				Action: plans.Update,
+			},
+			expected: "(sensitive value)",
+		},
+		"sensitive_update_hashes_enabled": {
+			diff: computed.Diff{
+				Renderer: Sensitive(computed.Diff{
+					Renderer: Primitive(json.Number("0"), json.Number("1"), cty.Number),
+					Action:   plans.Update,
+				}, true, true),
+				Action: plans.Update,
+			},
+			opts: computed.RenderHumanOpts{
+				ShowSensitiveHashes: true,
+			},
+			expected: "(sensitive value, hash: 24c91b9da5847df47064d89373c0c0ddba2d1ece4e45ed751d771a5b3718b9e8)",
+		},
+		"sensitive_update_hashes_salted": {
+			diff: computed.Diff{
+				Renderer: Sensitive(computed.Diff{
+					Renderer: Primitive(json.Number("0"), json.Number("1"), cty.Number),
+					Action:   plans.Update,
+				}, true, true),
+				Action: plans.Update,
+			},
+			opts: computed.RenderHumanOpts{
+				ShowSensitiveHashes: true,
+				SensitiveHashSalt:   []byte("some-per-render-salt"),
+			},
+			expected: "(sensitive value, hash: 4d6ccee181db4c1982c0fa9539521d2bf44d3b243dff26a835ae56a60630a143)",
+		},
 	}
 	for name, tc := range tcs {
 		t.Run(name, func(t *testing.T) {
@@ -2244,3 +2467,146 @@ jsonencode(
 		})
 	}
 }
+
+func TestRenderers_JSON(t *testing.T) {
+	tcs := map[string]struct {
+		diff     computed.Diff
+		expected computed.DiffJSON
+	}{
+		"primitive_update": {
+			diff: computed.Diff{
+				Renderer: Primitive("before", "after", cty.String),
+				Action:   plans.Update,
+			},
+			expected: computed.DiffJSON{
+				Action: "Update",
+				Before: "before",
+				After:  "after",
+			},
+		},
+		"object_create": {
+			diff: computed.Diff{
+				Renderer: Object(map[string]computed.Diff{
+					"attribute_one": {
+						Renderer: Primitive(nil, "new", cty.String),
+						Action:   plans.Create,
+					},
+				}),
+				Action: plans.Create,
+			},
+			expected: computed.DiffJSON{
+				Action: "Create",
+				Children: map[string]computed.DiffJSON{
+					"attribute_one": {
+						Action: "Create",
+						After:  "new",
+					},
+				},
+			},
+		},
+		"list_update": {
+			diff: computed.Diff{
+				Renderer: List([]computed.Diff{
+					{
+						Renderer: Primitive("one", "one", cty.String),
+						Action:   plans.NoOp,
+					},
+					{
+						Renderer: Primitive(nil, "two", cty.String),
+						Action:   plans.Create,
+					},
+				}),
+				Action: plans.Update,
+			},
+			expected: computed.DiffJSON{
+				Action: "Update",
+				Children: []computed.DiffJSON{
+					{Action: "NoOp", Before: "one", After: "one"},
+					{Action: "Create", After: "two"},
+				},
+			},
+		},
+		"sensitive_update": {
+			diff: computed.Diff{
+				Renderer: Sensitive(computed.Diff{
+					Renderer: Primitive("old", "new", cty.String),
+					Action:   plans.Update,
+				}, true, true),
+				Action: plans.Update,
+			},
+			expected: computed.DiffJSON{
+				Action:    "Update",
+				Sensitive: true,
+			},
+		},
+		"unknown_update": {
+			diff: computed.Diff{
+				Renderer: Unknown(computed.Diff{
+					Renderer: Primitive("old", nil, cty.String),
+					Action:   plans.Delete,
+				}),
+				Action: plans.Update,
+			},
+			expected: computed.DiffJSON{
+				Action:  "Update",
+				Unknown: true,
+				Before: computed.DiffJSON{
+					Action: "Delete",
+					Before: "old",
+				},
+			},
+		},
+	}
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			actual := tc.diff.RenderJSON()
+			if diff := cmp.Diff(tc.expected, actual); len(diff) > 0 {
+				t.Fatalf("\ndiff:\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestSetRenderer_StableOrder(t *testing.T) {
+	colorize := colorstring.Colorize{
+		Colors:  colorstring.DefaultColors,
+		Disable: true,
+	}
+	opts := computed.RenderHumanOpts{
+		Colorize:       &colorize,
+		StableSetOrder: true,
+	}
+
+	element := func(before, after interface{}, action plans.Action) computed.Diff {
+		return computed.Diff{
+			Renderer: Primitive(before, after, cty.String),
+			Action:   action,
+		}
+	}
+
+	orderings := [][]computed.Diff{
+		{
+			element(nil, "one", plans.Create),
+			element(nil, "two", plans.Create),
+			element("three", nil, plans.Delete),
+		},
+		{
+			element("three", nil, plans.Delete),
+			element(nil, "two", plans.Create),
+			element(nil, "one", plans.Create),
+		},
+	}
+
+	var outputs []string
+	for _, elements := range orderings {
+		diff := computed.Diff{
+			Renderer: Set(elements),
+			Action:   plans.Update,
+		}
+		outputs = append(outputs, diff.RenderHuman(0, opts))
+	}
+
+	if outputs[0] != outputs[1] {
+		t.Fatalf("expected shuffled set elements to render identically with StableSetOrder set\nfirst:\n%s\nsecond:\n%s", outputs[0], outputs[1])
+	}
+}
@@ -4,6 +4,9 @@
 package renderers
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 
 	"github.com/hashicorp/terraform/internal/command/jsonformat/computed"
@@ -28,9 +31,41 @@ type sensitiveRenderer struct {
 }
 
 func (renderer sensitiveRenderer) RenderHuman(diff computed.Diff, indent int, opts computed.RenderHumanOpts) string {
+	if opts.ShowSensitiveHashes {
+		return fmt.Sprintf("(sensitive value, hash: %s)%s%s", renderer.hash(opts.SensitiveHashSalt), nullSuffix(diff.Action, opts), forcesReplacement(diff.Replace, opts))
+	}
 	return fmt.Sprintf("(sensitive value)%s%s", nullSuffix(diff.Action, opts), forcesReplacement(diff.Replace, opts))
 }
 
+// hash returns a salted SHA-256 hash of the JSON representation of the
+// underlying change (its action and its before and after values), so
+// callers can tell whether a sensitive value changed
+// between two plans without ever printing the value itself. The salt is
+// mixed in ahead of the value so that a low-entropy sensitive value (a
+// short password, a small token) can't be recovered from the hash via a
+// dictionary or rainbow-table attack.
+func (renderer sensitiveRenderer) hash(salt []byte) string {
+	data, err := json.Marshal(renderer.inner.RenderJSON())
+	if err != nil {
+		// RenderJSON should always produce marshalable data; if it somehow
+		// doesn't, fall back to a fixed placeholder rather than panicking
+		// over a value we're specifically trying not to expose.
+		return "unavailable"
+	}
+	h := sha256.New()
+	h.Write(salt)
+	h.Write(data)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (renderer sensitiveRenderer) RenderJSON(diff computed.Diff) computed.DiffJSON {
+	return computed.DiffJSON{
+		Action:    diff.Action.String(),
+		Replace:   diff.Replace,
+		Sensitive: true,
+	}
+}
+
 func (renderer sensitiveRenderer) WarningsHuman(diff computed.Diff, indent int, opts computed.RenderHumanOpts) []string {
 	if (renderer.beforeSensitive == renderer.afterSensitive) || renderer.inner.Action == plans.Create || renderer.inner.Action == plans.Delete {
 		// Only display warnings for sensitive values if they are changing from
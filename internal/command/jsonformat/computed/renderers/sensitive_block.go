@@ -31,6 +31,14 @@ func (renderer sensitiveBlockRenderer) RenderHuman(diff computed.Diff, indent in
 		forcesReplacement(diff.Replace, opts), cachedLinePrefix, cachedLinePrefix, cachedLinePrefix)
 }
 
+func (renderer sensitiveBlockRenderer) RenderJSON(diff computed.Diff) computed.DiffJSON {
+	return computed.DiffJSON{
+		Action:    diff.Action.String(),
+		Replace:   diff.Replace,
+		Sensitive: true,
+	}
+}
+
 func (renderer sensitiveBlockRenderer) WarningsHuman(diff computed.Diff, indent int, opts computed.RenderHumanOpts) []string {
 	if (renderer.beforeSensitive == renderer.afterSensitive) || renderer.inner.Action == plans.Create || renderer.inner.Action == plans.Delete {
 		// Only display warnings for sensitive values if they are changing from
@@ -6,6 +6,7 @@ package renderers
 import (
 	"bytes"
 	"fmt"
+	"sort"
 
 	"github.com/hashicorp/terraform/internal/command/jsonformat/computed"
 	"github.com/hashicorp/terraform/internal/plans"
@@ -46,6 +47,18 @@ func (renderer setRenderer) RenderHuman(diff computed.Diff, indent int, opts com
 		return fmt.Sprintf("[]%s%s", nullSuffix(diff.Action, opts), forcesReplacement(displayForcesReplacementInSelf, opts))
 	}
 
+	if opts.MaxDepth > 0 && indent >= opts.MaxDepth {
+		return collapsedSummary("[", "]", diff, len(renderer.elements), opts)
+	}
+
+	elements := renderer.elements
+	if opts.StableSetOrder {
+		elements = append([]computed.Diff(nil), elements...)
+		sort.SliceStable(elements, func(i, j int) bool {
+			return setElementSortKey(elements[i]) < setElementSortKey(elements[j])
+		})
+	}
+
 	elementOpts := opts.Clone()
 	elementOpts.OverrideNullSuffix = true
 	elementOpts.ForceForcesReplacement = displayForcesReplacementInChildren
@@ -54,7 +67,7 @@ func (renderer setRenderer) RenderHuman(diff computed.Diff, indent int, opts com
 
 	var buf bytes.Buffer
 	buf.WriteString(fmt.Sprintf("[%s\n", forcesReplacement(displayForcesReplacementInSelf, opts)))
-	for _, element := range renderer.elements {
+	for _, element := range elements {
 		if element.Action == plans.NoOp && !opts.ShowUnchangedChildren {
 			unchangedElements++
 			continue
@@ -73,3 +86,15 @@ func (renderer setRenderer) RenderHuman(diff computed.Diff, indent int, opts com
 	buf.WriteString(fmt.Sprintf("%s%s]%s", formatIndent(indent), writeDiffActionSymbol(plans.NoOp, opts), nullSuffix(diff.Action, opts)))
 	return buf.String()
 }
+
+func (renderer setRenderer) RenderJSON(diff computed.Diff) computed.DiffJSON {
+	children := make([]computed.DiffJSON, 0, len(renderer.elements))
+	for _, element := range renderer.elements {
+		children = append(children, element.RenderJSON())
+	}
+	return computed.DiffJSON{
+		Action:   diff.Action.String(),
+		Replace:  diff.Replace,
+		Children: children,
+	}
+}
@@ -29,3 +29,12 @@ func (renderer typeChangeRenderer) RenderHuman(diff computed.Diff, indent int, o
 	opts.OverrideNullSuffix = true // Never render null suffix for children of type changes.
 	return fmt.Sprintf("%s %s %s", renderer.before.RenderHuman(indent, opts), opts.Colorize.Color("[yellow]->[reset]"), renderer.after.RenderHuman(indent, opts))
 }
+
+func (renderer typeChangeRenderer) RenderJSON(diff computed.Diff) computed.DiffJSON {
+	return computed.DiffJSON{
+		Action:  diff.Action.String(),
+		Replace: diff.Replace,
+		Before:  renderer.before.RenderJSON(),
+		After:   renderer.after.RenderJSON(),
+	}
+}
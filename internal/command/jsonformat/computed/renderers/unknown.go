@@ -43,5 +43,22 @@ func (renderer unknownRenderer) RenderHuman(diff computed.Diff, indent int, opts
 		// change, then do not display it for the before specifically.
 		beforeOpts.ForbidForcesReplacement = true
 	}
+	// renderer.before.RenderHuman handles its own indentation, so a complex
+	// prior value (an object, map, or multiline string) renders its full
+	// before-state inline at the current indent level, followed by the
+	// "-> (known after apply)" suffix on the same line as its closing
+	// delimiter.
 	return fmt.Sprintf("%s -> (known after apply)%s", renderer.before.RenderHuman(indent, beforeOpts), forcesReplacement(diff.Replace, opts))
 }
+
+func (renderer unknownRenderer) RenderJSON(diff computed.Diff) computed.DiffJSON {
+	result := computed.DiffJSON{
+		Action:  diff.Action.String(),
+		Replace: diff.Replace,
+		Unknown: true,
+	}
+	if renderer.before.Renderer != nil {
+		result.Before = renderer.before.RenderJSON()
+	}
+	return result
+}
@@ -4,6 +4,7 @@
 package renderers
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -49,6 +50,25 @@ func formatIndent(indent int) string {
 	return strings.Repeat("    ", indent)
 }
 
+// collapsedSummary renders the placeholder used in place of a fully expanded
+// Object, Block, Map, List, or Set once RenderHumanOpts.MaxDepth has been
+// reached, so deeply nested diffs don't become unreadable. count is the
+// number of direct children of the collapsed value, changed or not.
+func collapsedSummary(open, close string, diff computed.Diff, count int, opts computed.RenderHumanOpts) string {
+	noun := "change"
+	if count != 1 {
+		noun = "changes"
+	}
+	return fmt.Sprintf("%s ... %d nested %s %s%s%s", open, count, noun, close, nullSuffix(diff.Action, opts), forcesReplacement(diff.Replace, opts))
+}
+
+// computedIDsSummary renders the single-line placeholder used in place of a
+// list of id-like attributes that are all becoming known after apply, when
+// RenderHumanOpts.CollapseComputedIDs is set.
+func computedIDsSummary(ids []string, opts computed.RenderHumanOpts) string {
+	return opts.Colorize.Color(fmt.Sprintf("[dark_gray]# (%d computed ids become known after apply: %s)[reset]", len(ids), strings.Join(ids, ", ")))
+}
+
 // unchanged prints out a description saying how many of 'keyword' have been
 // hidden because they are unchanged or noop actions.
 func unchanged(keyword string, count int, opts computed.RenderHumanOpts) string {
@@ -79,6 +99,20 @@ func hclEscapeString(str string) string {
 	return fmt.Sprintf("%q", str)
 }
 
+// setElementSortKey returns a stable, deterministic string for a set
+// element's diff, derived from its JSON representation, suitable for sorting
+// elements into a consistent display order when RenderHumanOpts.StableSetOrder
+// is set.
+func setElementSortKey(diff computed.Diff) string {
+	data, err := json.Marshal(diff.RenderJSON())
+	if err != nil {
+		// RenderJSON always returns a plain struct of serializable types, so
+		// this should never actually happen.
+		return ""
+	}
+	return string(data)
+}
+
 // writeDiffActionSymbol writes out the symbols for the associated action, and
 // handles localized colorization of the symbol as well as indenting the symbol
 // to be 4 spaces wide.
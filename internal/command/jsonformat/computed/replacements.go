@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package computed
+
+import (
+	"sort"
+	"strconv"
+)
+
+// ForcedReplacement records that applying the attribute or block at Path,
+// within the resource at Address, would force Terraform to replace that
+// resource instead of updating it in place.
+type ForcedReplacement struct {
+	Address string
+	Path    []string
+}
+
+// ForcedReplacements walks a set of per-resource diffs, keyed by resource
+// address, and returns every attribute or block path that is marked as
+// forcing a replacement, so a caller can summarize a whole plan as (for
+// example) "3 resources will be replaced" along with the reasons why.
+//
+// The returned slice is sorted by address and then by path, so the result is
+// stable across calls for the same input.
+func ForcedReplacements(diffs map[string]Diff) []ForcedReplacement {
+	var addresses []string
+	for address := range diffs {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	var replacements []ForcedReplacement
+	for _, address := range addresses {
+		for _, path := range forcedReplacementPaths(nil, diffs[address].RenderJSON()) {
+			replacements = append(replacements, ForcedReplacement{
+				Address: address,
+				Path:    path,
+			})
+		}
+	}
+	return replacements
+}
+
+// forcedReplacementPaths returns every path (relative to prefix) beneath and
+// including diff that has Replace set, walking into whichever concrete shape
+// diff.Children happens to be.
+func forcedReplacementPaths(prefix []string, diff DiffJSON) [][]string {
+	var paths [][]string
+	if diff.Replace {
+		paths = append(paths, append([]string(nil), prefix...))
+	}
+
+	switch children := diff.Children.(type) {
+	case map[string]DiffJSON:
+		var keys []string
+		for key := range children {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			childPrefix := append(append([]string(nil), prefix...), key)
+			paths = append(paths, forcedReplacementPaths(childPrefix, children[key])...)
+		}
+	case []DiffJSON:
+		for ix, child := range children {
+			childPrefix := append(append([]string(nil), prefix...), strconv.Itoa(ix))
+			paths = append(paths, forcedReplacementPaths(childPrefix, child)...)
+		}
+	}
+	return paths
+}
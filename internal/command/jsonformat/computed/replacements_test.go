@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package computed
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform/internal/plans"
+)
+
+type fakeReplaceRenderer struct {
+	children map[string]Diff
+}
+
+func (r fakeReplaceRenderer) RenderHuman(diff Diff, indent int, opts RenderHumanOpts) string {
+	return ""
+}
+
+func (r fakeReplaceRenderer) WarningsHuman(diff Diff, indent int, opts RenderHumanOpts) []string {
+	return nil
+}
+
+func (r fakeReplaceRenderer) RenderJSON(diff Diff) DiffJSON {
+	children := make(map[string]DiffJSON, len(r.children))
+	for key, child := range r.children {
+		children[key] = child.RenderJSON()
+	}
+	return DiffJSON{
+		Action:   diff.Action.String(),
+		Replace:  diff.Replace,
+		Children: children,
+	}
+}
+
+type fakeLeafRenderer struct{}
+
+func (r fakeLeafRenderer) RenderHuman(diff Diff, indent int, opts RenderHumanOpts) string {
+	return ""
+}
+
+func (r fakeLeafRenderer) WarningsHuman(diff Diff, indent int, opts RenderHumanOpts) []string {
+	return nil
+}
+
+func (r fakeLeafRenderer) RenderJSON(diff Diff) DiffJSON {
+	return DiffJSON{Action: diff.Action.String(), Replace: diff.Replace}
+}
+
+func TestForcedReplacements(t *testing.T) {
+	diffs := map[string]Diff{
+		"test_instance.no_changes": {
+			Renderer: fakeReplaceRenderer{children: map[string]Diff{
+				"ami": {Renderer: fakeLeafRenderer{}, Action: plans.NoOp},
+			}},
+			Action: plans.Update,
+		},
+		"test_instance.replaced": {
+			Renderer: fakeReplaceRenderer{children: map[string]Diff{
+				"ami": {Renderer: fakeLeafRenderer{}, Action: plans.Update, Replace: true},
+				"tags": {Renderer: fakeReplaceRenderer{children: map[string]Diff{
+					"name": {Renderer: fakeLeafRenderer{}, Action: plans.Update, Replace: true},
+				}}, Action: plans.Update},
+			}},
+			Action: plans.Update,
+		},
+	}
+
+	got := ForcedReplacements(diffs)
+	want := []ForcedReplacement{
+		{Address: "test_instance.replaced", Path: []string{"ami"}},
+		{Address: "test_instance.replaced", Path: []string{"tags", "name"}},
+	}
+
+	if diff := cmp.Diff(want, got); len(diff) > 0 {
+		t.Fatalf("unexpected replacements\ndiff:\n%s", diff)
+	}
+}
@@ -384,12 +384,13 @@ func renderHumanDiff(renderer Renderer, diff diff, cause string) (string, bool)
 	}
 
 	var buf bytes.Buffer
-	buf.WriteString(renderer.Colorize.Color(resourceChangeComment(diff.change, action, cause)))
+	buf.WriteString(renderer.Colorize.Color(resourceChangeComment(diff.change, action, cause, renderer.ShowModuleAnnotations, renderer.ShowReplaceOrder)))
 
 	opts := computed.NewRenderHumanOpts(renderer.Colorize)
 	opts.ShowUnchangedChildren = diff.Importing()
 
 	buf.WriteString(fmt.Sprintf("%s %s %s", renderer.Colorize.Color(format.DiffActionSymbol(action)), resourceChangeHeader(diff.change), diff.diff.RenderHuman(0, opts)))
+
 	return buf.String(), true
 }
 
@@ -435,7 +436,7 @@ func renderHumanDeferredDiff(renderer Renderer, deferred deferredDiff) (string,
 	return buf.String(), true
 }
 
-func resourceChangeComment(resource jsonplan.ResourceChange, action plans.Action, changeCause string) string {
+func resourceChangeComment(resource jsonplan.ResourceChange, action plans.Action, changeCause string, showModuleAnnotations, showReplaceOrder bool) string {
 	var buf bytes.Buffer
 
 	dispAddr := resource.Address
@@ -479,6 +480,14 @@ func resourceChangeComment(resource jsonplan.ResourceChange, action plans.Action
 		default:
 			buf.WriteString(fmt.Sprintf("[bold]  # %s[reset] must be [bold][red]replaced[reset]", dispAddr))
 		}
+		if showReplaceOrder {
+			switch action {
+			case plans.CreateThenDelete:
+				buf.WriteString(" (create replacement before destroying)")
+			case plans.DeleteThenCreate:
+				buf.WriteString(" (destroying before creating replacement)")
+			}
+		}
 	case plans.CreateThenForget:
 		buf.WriteString(fmt.Sprintf("[bold] # %s[reset] must be replaced, but the existing object will not be destroyed", dispAddr))
 		buf.WriteString("\n # (destroy = false is set in the configuration)")
@@ -584,6 +593,9 @@ func resourceChangeComment(resource jsonplan.ResourceChange, action plans.Action
 	if resource.Change.Importing != nil && (action == plans.CreateThenDelete || action == plans.DeleteThenCreate) {
 		buf.WriteString("  # [reset][yellow]Warning: this will destroy the imported resource[reset]\n")
 	}
+	if showModuleAnnotations && len(resource.ModuleAddress) > 0 {
+		buf.WriteString(fmt.Sprintf("  # [reset](within %s)\n", resource.ModuleAddress))
+	}
 
 	return buf.String()
 }
@@ -6,6 +6,7 @@ package jsonformat
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -500,6 +501,168 @@ Plan: 1 to import, 1 to add, 0 to change, 1 to destroy.
 	}
 }
 
+func TestRenderHuman_ModuleAnnotations(t *testing.T) {
+	color := &colorstring.Colorize{Colors: colorstring.DefaultColors, Disable: true}
+
+	schemas := map[string]*jsonprovider.Provider{
+		"test": {
+			ResourceSchemas: map[string]*jsonprovider.Schema{
+				"test_resource": {
+					Block: &jsonprovider.Block{
+						Attributes: map[string]*jsonprovider.Attribute{
+							"id": {
+								AttributeType: marshalJson(t, "string"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	plan := Plan{
+		PlanFormatVersion:     jsonplan.FormatVersion,
+		ProviderFormatVersion: jsonprovider.FormatVersion,
+		ProviderSchemas:       schemas,
+		ResourceChanges: []jsonplan.ResourceChange{
+			{
+				Address:       "module.child.test_resource.resource",
+				ModuleAddress: "module.child",
+				Mode:          "managed",
+				Type:          "test_resource",
+				Name:          "resource",
+				ProviderName:  "test",
+				Change: jsonplan.Change{
+					Actions: []string{"create"},
+					Before:  marshalJson(t, nil),
+					After: marshalJson(t, map[string]interface{}{
+						"id": "1D5F5E9E-F2E5-401B-9ED5-692A215AC67E",
+					}),
+				},
+			},
+		},
+	}
+
+	t.Run("shown when enabled", func(t *testing.T) {
+		streams, done := terminal.StreamsForTesting(t)
+		renderer := Renderer{
+			Colorize:              color,
+			Streams:               streams,
+			ShowModuleAnnotations: true,
+		}
+		plan.renderHuman(renderer, plans.NormalMode)
+
+		got := done(t).Stdout()
+		if !strings.Contains(got, "(within module.child)") {
+			t.Errorf("expected output to annotate the change with its module path, got:\n%s", got)
+		}
+	})
+
+	t.Run("hidden when disabled", func(t *testing.T) {
+		streams, done := terminal.StreamsForTesting(t)
+		renderer := Renderer{
+			Colorize: color,
+			Streams:  streams,
+		}
+		plan.renderHuman(renderer, plans.NormalMode)
+
+		got := done(t).Stdout()
+		if strings.Contains(got, "(within module.child)") {
+			t.Errorf("expected output not to contain a module path annotation, got:\n%s", got)
+		}
+	})
+}
+
+func TestRenderHuman_ReplaceOrder(t *testing.T) {
+	color := &colorstring.Colorize{Colors: colorstring.DefaultColors, Disable: true}
+
+	schemas := map[string]*jsonprovider.Provider{
+		"test": {
+			ResourceSchemas: map[string]*jsonprovider.Schema{
+				"test_resource": {
+					Block: &jsonprovider.Block{
+						Attributes: map[string]*jsonprovider.Attribute{
+							"id": {
+								AttributeType: marshalJson(t, "string"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	planFor := func(actions []string) Plan {
+		return Plan{
+			PlanFormatVersion:     jsonplan.FormatVersion,
+			ProviderFormatVersion: jsonprovider.FormatVersion,
+			ProviderSchemas:       schemas,
+			ResourceChanges: []jsonplan.ResourceChange{
+				{
+					Address:      "test_resource.resource",
+					Mode:         "managed",
+					Type:         "test_resource",
+					Name:         "resource",
+					ProviderName: "test",
+					Change: jsonplan.Change{
+						Actions: actions,
+						Before: marshalJson(t, map[string]interface{}{
+							"id": "before",
+						}),
+						After: marshalJson(t, map[string]interface{}{
+							"id": "after",
+						}),
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("create before destroy, shown when enabled", func(t *testing.T) {
+		streams, done := terminal.StreamsForTesting(t)
+		renderer := Renderer{
+			Colorize:         color,
+			Streams:          streams,
+			ShowReplaceOrder: true,
+		}
+		planFor([]string{"create", "delete"}).renderHuman(renderer, plans.NormalMode)
+
+		got := done(t).Stdout()
+		if !strings.Contains(got, "(create replacement before destroying)") {
+			t.Errorf("expected output to note the replacement order, got:\n%s", got)
+		}
+	})
+
+	t.Run("destroy before create, shown when enabled", func(t *testing.T) {
+		streams, done := terminal.StreamsForTesting(t)
+		renderer := Renderer{
+			Colorize:         color,
+			Streams:          streams,
+			ShowReplaceOrder: true,
+		}
+		planFor([]string{"delete", "create"}).renderHuman(renderer, plans.NormalMode)
+
+		got := done(t).Stdout()
+		if !strings.Contains(got, "(destroying before creating replacement)") {
+			t.Errorf("expected output to note the replacement order, got:\n%s", got)
+		}
+	})
+
+	t.Run("hidden when disabled", func(t *testing.T) {
+		streams, done := terminal.StreamsForTesting(t)
+		renderer := Renderer{
+			Colorize: color,
+			Streams:  streams,
+		}
+		planFor([]string{"delete", "create"}).renderHuman(renderer, plans.NormalMode)
+
+		got := done(t).Stdout()
+		if strings.Contains(got, "before destroying") || strings.Contains(got, "before creating replacement") {
+			t.Errorf("expected output not to contain a replacement order annotation, got:\n%s", got)
+		}
+	})
+}
+
 func TestResourceChange_primitiveTypes(t *testing.T) {
 	testCases := map[string]testCase{
 		"creation": {
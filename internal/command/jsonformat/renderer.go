@@ -110,6 +110,21 @@ type Renderer struct {
 	Colorize *colorstring.Colorize
 
 	RunningInAutomation bool
+
+	// ShowModuleAnnotations tells the renderer to print which module a
+	// resource change belongs to, alongside the change's resource address.
+	// This is off by default since the module path is already included in
+	// the resource address itself, but it can be useful to call it out
+	// separately for plans with deeply-nested modules.
+	ShowModuleAnnotations bool
+
+	// ShowReplaceOrder tells the renderer to note, alongside a replacement
+	// change's main comment, whether the replacement will create the new
+	// object before destroying the old one or the reverse. This is off by
+	// default because the plan's action legend already distinguishes the
+	// two via its "create replacement and then destroy" / "destroy and then
+	// create replacement" entries.
+	ShowReplaceOrder bool
 }
 
 func (renderer Renderer) RenderHumanPlan(plan Plan, mode plans.Mode, opts ...plans.Quality) {
@@ -33,19 +33,19 @@ import (
 const (
 	FormatVersion = "1.2"
 
-	ResourceInstanceReplaceBecauseCannotUpdate    = "replace_because_cannot_update"
-	ResourceInstanceReplaceBecauseTainted         = "replace_because_tainted"
-	ResourceInstanceReplaceByRequest              = "replace_by_request"
-	ResourceInstanceReplaceByTriggers             = "replace_by_triggers"
-	ResourceInstanceDeleteBecauseNoResourceConfig = "delete_because_no_resource_config"
-	ResourceInstanceDeleteBecauseWrongRepetition  = "delete_because_wrong_repetition"
-	ResourceInstanceDeleteBecauseCountIndex       = "delete_because_count_index"
-	ResourceInstanceDeleteBecauseEachKey          = "delete_because_each_key"
-	ResourceInstanceDeleteBecauseNoModule         = "delete_because_no_module"
-	ResourceInstanceDeleteBecauseNoMoveTarget     = "delete_because_no_move_target"
-	ResourceInstanceReadBecauseConfigUnknown      = "read_because_config_unknown"
-	ResourceInstanceReadBecauseDependencyPending  = "read_because_dependency_pending"
-	ResourceInstanceReadBecauseCheckNested        = "read_because_check_nested"
+	ResourceInstanceReplaceBecauseCannotUpdate     = "replace_because_cannot_update"
+	ResourceInstanceReplaceBecauseTainted          = "replace_because_tainted"
+	ResourceInstanceReplaceByRequest               = "replace_by_request"
+	ResourceInstanceReplaceByTriggers              = "replace_by_triggers"
+	ResourceInstanceDeleteBecauseNoResourceConfig  = "delete_because_no_resource_config"
+	ResourceInstanceDeleteBecauseWrongRepetition   = "delete_because_wrong_repetition"
+	ResourceInstanceDeleteBecauseCountIndex        = "delete_because_count_index"
+	ResourceInstanceDeleteBecauseEachKey           = "delete_because_each_key"
+	ResourceInstanceDeleteBecauseNoModule          = "delete_because_no_module"
+	ResourceInstanceDeleteBecauseNoMoveTarget      = "delete_because_no_move_target"
+	ResourceInstanceReadBecauseConfigUnknown       = "read_because_config_unknown"
+	ResourceInstanceReadBecauseDependencyPending   = "read_because_dependency_pending"
+	ResourceInstanceReadBecauseCheckNested         = "read_because_check_nested"
 
 	DeferredReasonUnknown               = "unknown"
 	DeferredReasonInstanceCountUnknown  = "instance_count_unknown"
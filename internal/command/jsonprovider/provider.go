@@ -28,6 +28,11 @@ type Provider struct {
 	DataSourceSchemas        map[string]*Schema                         `json:"data_source_schemas,omitempty"`
 	EphemeralResourceSchemas map[string]*Schema                         `json:"ephemeral_resource_schemas,omitempty"`
 	Functions                map[string]*jsonfunction.FunctionSignature `json:"functions,omitempty"`
+
+	// ProviderMeta is the schema for the provider_meta block that modules
+	// using this provider may declare. It is omitted for providers that
+	// don't define one.
+	ProviderMeta *Schema `json:"provider_meta,omitempty"`
 }
 
 func newProviders() *Providers {
@@ -58,11 +63,15 @@ func Marshal(s *terraform.Schemas) ([]byte, error) {
 }
 
 func marshalProvider(tps providers.ProviderSchema) *Provider {
-	return &Provider{
+	provider := &Provider{
 		Provider:                 marshalSchema(tps.Provider),
 		ResourceSchemas:          marshalSchemas(tps.ResourceTypes),
 		DataSourceSchemas:        marshalSchemas(tps.DataSources),
 		EphemeralResourceSchemas: marshalSchemas(tps.EphemeralResourceTypes),
 		Functions:                jsonfunction.MarshalProviderFunctions(tps.Functions),
 	}
+	if tps.ProviderMeta.Block != nil {
+		provider.ProviderMeta = marshalSchema(tps.ProviderMeta)
+	}
+	return provider
 }
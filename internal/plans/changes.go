@@ -267,8 +267,9 @@ type ResourceInstanceChange struct {
 	// Replace rather than Update. Always nil if the change action is not
 	// Replace.
 	//
-	// This is retained only for UI-plan-rendering purposes and so it does not
-	// currently survive a round-trip through a saved plan file.
+	// This is retained for UI-plan-rendering purposes, such as annotating
+	// "# forces replacement" against the attributes responsible. It survives
+	// a round-trip through a saved plan file via Encode/Decode.
 	RequiredReplace cty.PathSet
 
 	// Private allows a provider to stash any extra data that is opaque to
@@ -44,6 +44,50 @@ func TestChangeEncodeSensitive(t *testing.T) {
 	}
 }
 
+func TestResourceInstanceChangeEncodeRequiredReplace(t *testing.T) {
+	ty := cty.Object(map[string]cty.Type{
+		"id":  cty.String,
+		"ami": cty.String,
+	})
+	rc := &ResourceInstanceChange{
+		Change: Change{
+			Action: DeleteThenCreate,
+			Before: cty.ObjectVal(map[string]cty.Value{
+				"id":  cty.StringVal("foo"),
+				"ami": cty.StringVal("ami-old"),
+			}),
+			After: cty.ObjectVal(map[string]cty.Value{
+				"id":  cty.UnknownVal(cty.String),
+				"ami": cty.StringVal("ami-new"),
+			}),
+		},
+		RequiredReplace: cty.NewPathSet(
+			cty.GetAttrPath("id"),
+			cty.GetAttrPath("ami"),
+		),
+	}
+
+	encoded, err := rc.Encode(ty)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := encoded.Decode(ty)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := decoded.RequiredReplace
+	for _, want := range []cty.Path{cty.GetAttrPath("id"), cty.GetAttrPath("ami")} {
+		if !got.Has(want) {
+			t.Fatalf("decoded RequiredReplace is missing path %#v; got %#v", want, got.List())
+		}
+	}
+	if gotLen := len(got.List()); gotLen != 2 {
+		t.Fatalf("wrong number of required-replace paths after round-trip: got %d, want 2", gotLen)
+	}
+}
+
 // make sure we get a valid value back even when faced with an error
 func TestChangeEncodeError(t *testing.T) {
 	changes := &Changes{
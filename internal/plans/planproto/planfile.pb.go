@@ -163,6 +163,7 @@ const (
 	ResourceInstanceActionReason_READ_BECAUSE_DEPENDENCY_PENDING   ResourceInstanceActionReason = 11
 	ResourceInstanceActionReason_READ_BECAUSE_CHECK_NESTED         ResourceInstanceActionReason = 13
 	ResourceInstanceActionReason_DELETE_BECAUSE_NO_MOVE_TARGET     ResourceInstanceActionReason = 12
+	ResourceInstanceActionReason_REPLACE_BECAUSE_PROVIDER_DEFERRED ResourceInstanceActionReason = 14
 )
 
 // Enum value maps for ResourceInstanceActionReason.
@@ -182,6 +183,7 @@ var (
 		11: "READ_BECAUSE_DEPENDENCY_PENDING",
 		13: "READ_BECAUSE_CHECK_NESTED",
 		12: "DELETE_BECAUSE_NO_MOVE_TARGET",
+		14: "REPLACE_BECAUSE_PROVIDER_DEFERRED",
 	}
 	ResourceInstanceActionReason_value = map[string]int32{
 		"NONE":                              0,
@@ -198,6 +200,7 @@ var (
 		"READ_BECAUSE_DEPENDENCY_PENDING":   11,
 		"READ_BECAUSE_CHECK_NESTED":         13,
 		"DELETE_BECAUSE_NO_MOVE_TARGET":     12,
+		"REPLACE_BECAUSE_PROVIDER_DEFERRED": 14,
 	}
 )
 
@@ -106,6 +106,15 @@ type GetProviderSchemaResponse struct {
 	// ProviderMeta is the schema for the provider's meta info in a module
 	ProviderMeta Schema
 
+	// ProviderMetaSchemas, if populated, lets a provider declare more than
+	// one version of its provider_meta schema, keyed by schema version. Most
+	// providers only ever have a single provider_meta schema and so leave
+	// this unset, relying on ProviderMeta alone. A provider whose
+	// provider_meta schema has changed shape over time can populate this
+	// instead, and Terraform will select the newest version it understands
+	// via [ProviderSchema.SelectProviderMetaSchema].
+	ProviderMetaSchemas map[int64]Schema
+
 	// ResourceTypes map the resource type name to that type's schema.
 	ResourceTypes map[string]Schema
 
@@ -156,6 +165,23 @@ type ServerCapabilities struct {
 	// The MoveResourceState capability indicates that this provider supports
 	// the MoveResourceState RPC.
 	MoveResourceState bool
+
+	// The DeterministicComputedValues capability indicates that this
+	// provider's computed attribute values never depend on the resource's
+	// prior state, only on its configuration. When set, Terraform can skip
+	// the extra PlanResourceChange call it would otherwise make with a null
+	// prior state to recompute a replacement object's computed values,
+	// reusing the computed values from the initial plan instead.
+	DeterministicComputedValues bool
+
+	// The DeterministicPlanning capability indicates that this provider's
+	// PlanResourceChange result for a resource instance depends only on
+	// that instance's prior state and configuration, and calling it again
+	// with the same prior state and configuration always produces the same
+	// result. When set, and an earlier plan for the same prior state and
+	// configuration was a no-op, Terraform may reuse that earlier result
+	// instead of calling PlanResourceChange again.
+	DeterministicPlanning bool
 }
 
 // ClientCapabilities allows Terraform to publish information regarding
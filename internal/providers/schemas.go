@@ -4,6 +4,8 @@
 package providers
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/terraform/internal/addrs"
 	"github.com/hashicorp/terraform/internal/configs/configschema"
 )
@@ -37,3 +39,38 @@ func (ss ProviderSchema) SchemaForResourceType(mode addrs.ResourceMode, typeName
 func (ss ProviderSchema) SchemaForResourceAddr(addr addrs.Resource) (schema *configschema.Block, version uint64) {
 	return ss.SchemaForResourceType(addr.Mode, addr.Type)
 }
+
+// SelectProviderMetaSchema negotiates which provider_meta schema version to
+// use for this provider.
+//
+// Most providers only ever declare one provider_meta schema, via the
+// ProviderMeta field, and that's what gets returned here. A provider whose
+// provider_meta schema has changed shape over time can instead (or as well)
+// populate ProviderMetaSchemas with one entry per version it supports;
+// SelectProviderMetaSchema then returns the entry with the highest version
+// number, since that's the most complete schema this version of Terraform
+// can ask the provider about.
+//
+// It returns an error if the provider doesn't declare a provider_meta schema
+// at all, which the caller should surface to the user as the provider not
+// supporting provider_meta blocks.
+func (ss ProviderSchema) SelectProviderMetaSchema() (Schema, error) {
+	var best Schema
+	haveSchema := false
+	for version, schema := range ss.ProviderMetaSchemas {
+		if !haveSchema || version > best.Version {
+			best = schema
+			best.Version = version
+			haveSchema = true
+		}
+	}
+	if haveSchema {
+		return best, nil
+	}
+
+	if ss.ProviderMeta.Block != nil {
+		return ss.ProviderMeta, nil
+	}
+
+	return Schema{}, fmt.Errorf("provider does not declare a provider_meta schema")
+}
@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package providers
+
+import (
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hashicorp/terraform/internal/configs/configschema"
+)
+
+func TestProviderSchema_SelectProviderMetaSchema(t *testing.T) {
+	t.Run("single legacy schema", func(t *testing.T) {
+		block := &configschema.Block{}
+		schema := ProviderSchema{
+			ProviderMeta: Schema{Version: 1, Block: block},
+		}
+
+		got, err := schema.SelectProviderMetaSchema()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got.Block != block {
+			t.Fatalf("wrong block selected")
+		}
+	})
+
+	t.Run("multiple versions selects the newest", func(t *testing.T) {
+		v1 := &configschema.Block{}
+		v2 := &configschema.Block{
+			Attributes: map[string]*configschema.Attribute{
+				"new_attr": {Type: cty.String, Optional: true},
+			},
+		}
+		schema := ProviderSchema{
+			ProviderMetaSchemas: map[int64]Schema{
+				1: {Block: v1},
+				2: {Block: v2},
+			},
+		}
+
+		got, err := schema.SelectProviderMetaSchema()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got.Block != v2 {
+			t.Fatalf("expected the newest (version 2) schema to be selected")
+		}
+		if got.Version != 2 {
+			t.Fatalf("expected selected schema's Version to be set to 2, got %d", got.Version)
+		}
+	})
+
+	t.Run("no schema at all is a mismatch", func(t *testing.T) {
+		schema := ProviderSchema{}
+
+		_, err := schema.SelectProviderMetaSchema()
+		if err == nil {
+			t.Fatal("expected an error, got none")
+		}
+	})
+}
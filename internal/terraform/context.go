@@ -9,6 +9,7 @@ import (
 	"log"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/zclconf/go-cty/cty"
 
@@ -61,6 +62,56 @@ type ContextOpts struct {
 	PreloadedProviderSchemas map[addrs.Provider]providers.ProviderSchema
 
 	UIInput UIInput
+
+	// PlanTimingProfiler, if set, receives the duration of each resource
+	// instance's PlanResourceChange call during a plan. Leave unset for no
+	// profiling overhead.
+	PlanTimingProfiler PlanTimingProfiler
+
+	// ProviderApplyLimiter, if set, caps how many ApplyResourceChange calls
+	// may be in flight at once for a single provider (across all of its
+	// configurations) during apply. Leave unset for unbounded per-provider apply concurrency,
+	// subject only to the overall -parallelism limit.
+	ProviderApplyLimiter *ProviderApplyLimiter
+
+	// PlanCache, if set, lets NodeAbstractResourceInstance.plan reuse a
+	// cached no-op plan result for a resource instance whose prior state
+	// and configuration match an earlier plan, for providers that
+	// advertise the DeterministicPlanning server capability. Leave unset
+	// to always call the provider's PlanResourceChange.
+	PlanCache ResourceInstancePlanCache
+
+	// SkipRedundantApplyTimeValidation, if set, allows apply to skip its
+	// ValidateResourceConfig call for a resource instance when the
+	// configuration is fully known and still produces the exact value that
+	// was already validated during plan. Defaults to false, which preserves
+	// the long-standing behavior of always revalidating during apply.
+	SkipRedundantApplyTimeValidation bool
+
+	// StrictLegacyPlanConsistency, if set, promotes AssertPlanValid failures
+	// from legacy-SDK providers from a tolerated [WARN] log line to a proper
+	// plan error, so that provider authors can catch these inconsistencies
+	// during development and testing. Defaults to false, which preserves
+	// the long-standing tolerant behavior needed by real-world providers
+	// using the legacy SDK's imprecise type system.
+	StrictLegacyPlanConsistency bool
+
+	// AssertPlanObjectCompatibility, if set, additionally runs
+	// objchange.AssertObjectCompatible between the proposed new value
+	// Terraform built from configuration and the planned value a provider
+	// actually returned, logging a [WARN] line on any mismatch. This can
+	// catch additional provider bugs beyond what AssertPlanValid checks for.
+	// Defaults to false, since this check is not yet proven safe for every
+	// real-world provider.
+	AssertPlanObjectCompatibility bool
+
+	// RefreshTimeout, if set to a positive duration, bounds how long a
+	// single resource instance's ReadResource call may run during refresh.
+	// A provider that doesn't respond within this duration causes that
+	// instance's refresh to fail with a timeout diagnostic instead of
+	// blocking the rest of the operation indefinitely. Defaults to zero,
+	// which preserves the long-standing behavior of waiting indefinitely.
+	RefreshTimeout time.Duration
 }
 
 // ContextMeta is metadata about the running context. This is information
@@ -100,6 +151,14 @@ type Context struct {
 	uiInput   UIInput
 	graphOpts *ContextGraphOpts
 
+	planTimingProfiler               PlanTimingProfiler
+	providerApplyLimiter             *ProviderApplyLimiter
+	planCache                        ResourceInstancePlanCache
+	skipRedundantApplyTimeValidation bool
+	strictLegacyPlanConsistency      bool
+	assertPlanObjectCompatibility    bool
+	refreshTimeout                   time.Duration
+
 	l                   sync.Mutex // Lock acquired during any task
 	parallelSem         Semaphore
 	providerInputConfig map[string]map[string]cty.Value
@@ -157,6 +216,14 @@ func NewContext(opts *ContextOpts) (*Context, tfdiags.Diagnostics) {
 		uiInput:   opts.UIInput,
 		graphOpts: &ContextGraphOpts{},
 
+		planTimingProfiler:               opts.PlanTimingProfiler,
+		providerApplyLimiter:             opts.ProviderApplyLimiter,
+		planCache:                        opts.PlanCache,
+		skipRedundantApplyTimeValidation: opts.SkipRedundantApplyTimeValidation,
+		strictLegacyPlanConsistency:      opts.StrictLegacyPlanConsistency,
+		assertPlanObjectCompatibility:    opts.AssertPlanObjectCompatibility,
+		refreshTimeout:                   opts.RefreshTimeout,
+
 		plugins: plugins,
 
 		parallelSem:         NewSemaphore(par),
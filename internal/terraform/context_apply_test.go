@@ -67,6 +67,175 @@ func TestContext2Apply_basic(t *testing.T) {
 	}
 }
 
+// concurrencyTrackingProvider wraps a MockProvider and records the maximum
+// number of ApplyResourceChange calls that were ever in flight at once. It
+// bypasses MockProvider's own internal lock, which otherwise serializes
+// every call and would hide any concurrency under test.
+type concurrencyTrackingProvider struct {
+	*testing_provider.MockProvider
+
+	mu             sync.Mutex
+	current        int
+	maxConcurrency int
+}
+
+func (p *concurrencyTrackingProvider) ApplyResourceChange(r providers.ApplyResourceChangeRequest) providers.ApplyResourceChangeResponse {
+	p.mu.Lock()
+	p.current++
+	if p.current > p.maxConcurrency {
+		p.maxConcurrency = p.current
+	}
+	p.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+	resp := testApplyFn(r)
+
+	p.mu.Lock()
+	p.current--
+	p.mu.Unlock()
+
+	return resp
+}
+
+func testContext2ApplyProviderLimiter(t *testing.T, limiter *ProviderApplyLimiter) int {
+	t.Helper()
+
+	m := testModule(t, "apply-count-variable")
+	p := &concurrencyTrackingProvider{MockProvider: testProvider("aws")}
+	p.PlanResourceChangeFn = testDiffFn
+
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("aws"): testProviderFuncFixed(p),
+		},
+		Parallelism:          10,
+		ProviderApplyLimiter: limiter,
+	})
+
+	plan, diags := ctx.Plan(m, states.NewState(), &PlanOpts{
+		Mode: plans.NormalMode,
+		SetVariables: InputValues{
+			"foo": &InputValue{
+				Value: cty.NumberIntVal(6),
+			},
+		},
+	})
+	assertNoErrors(t, diags)
+
+	_, diags = ctx.Apply(plan, m, nil)
+	if diags.HasErrors() {
+		t.Fatalf("diags: %s", diags.Err())
+	}
+
+	return p.maxConcurrency
+}
+
+func TestContext2Apply_providerApplyLimiter(t *testing.T) {
+	if got := testContext2ApplyProviderLimiter(t, NewProviderApplyLimiter(2)); got > 2 {
+		t.Fatalf("observed %d concurrent applies for a single provider; want at most 2", got)
+	}
+}
+
+func TestContext2Apply_providerApplyLimiterUnset(t *testing.T) {
+	if got := testContext2ApplyProviderLimiter(t, nil); got <= 2 {
+		t.Fatalf("observed %d concurrent applies without a limiter; want more than 2 to prove the cap above is actually doing something", got)
+	}
+}
+
+// validateCountingProvider wraps a MockProvider to count ValidateResourceConfig
+// calls per resource type, so tests can tell whether apply skipped its
+// redundant re-validation of an already-planned config.
+type validateCountingProvider struct {
+	*testing_provider.MockProvider
+
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func (p *validateCountingProvider) ValidateResourceConfig(r providers.ValidateResourceConfigRequest) providers.ValidateResourceConfigResponse {
+	p.mu.Lock()
+	if p.counts == nil {
+		p.counts = make(map[string]int)
+	}
+	p.counts[r.TypeName]++
+	p.mu.Unlock()
+
+	return p.MockProvider.ValidateResourceConfig(r)
+}
+
+func testContext2ApplySkipRedundantValidation(t *testing.T, skip bool) map[string]int {
+	t.Helper()
+
+	m := testModule(t, "apply-compute")
+	p := &validateCountingProvider{MockProvider: testProvider("aws")}
+	p.PlanResourceChangeFn = testDiffFn
+	p.ApplyResourceChangeFn = testApplyFn
+	p.GetProviderSchemaResponse = getProviderSchemaResponseFromProviderSchema(&providerSchema{
+		ResourceTypes: map[string]*configschema.Block{
+			"aws_instance": {
+				Attributes: map[string]*configschema.Attribute{
+					"num":           {Type: cty.Number, Optional: true},
+					"compute":       {Type: cty.String, Optional: true},
+					"compute_value": {Type: cty.String, Optional: true},
+					"foo":           {Type: cty.String, Optional: true},
+					"id":            {Type: cty.String, Computed: true},
+					"type":          {Type: cty.String, Computed: true},
+					"value":         {Type: cty.String, Computed: true},
+				},
+			},
+		},
+	})
+
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("aws"): testProviderFuncFixed(p),
+		},
+		SkipRedundantApplyTimeValidation: skip,
+	})
+
+	plan, diags := ctx.Plan(m, states.NewState(), &PlanOpts{
+		SetVariables: InputValues{
+			"value": &InputValue{
+				Value:      cty.NumberIntVal(1),
+				SourceType: ValueFromCaller,
+			},
+		},
+	})
+	assertNoErrors(t, diags)
+
+	p.mu.Lock()
+	p.counts = make(map[string]int)
+	p.mu.Unlock()
+
+	_, diags = ctx.Apply(plan, m, nil)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Err())
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.counts
+}
+
+func TestContext2Apply_skipRedundantApplyTimeValidation(t *testing.T) {
+	// aws_instance.foo has no unknown values at plan time, so with the
+	// optimization enabled it should not be re-validated during apply.
+	// aws_instance.bar depends on aws_instance.foo's computed "value", so
+	// its config is still unknown at plan time and must always be
+	// re-validated once that value becomes known during apply.
+	counts := testContext2ApplySkipRedundantValidation(t, true)
+	if got := counts["aws_instance"]; got != 1 {
+		t.Fatalf("wrong number of apply-time ValidateResourceConfig calls with the optimization enabled: got %d, want 1 (for aws_instance.bar only)", got)
+	}
+}
+
+func TestContext2Apply_skipRedundantApplyTimeValidationDisabled(t *testing.T) {
+	counts := testContext2ApplySkipRedundantValidation(t, false)
+	if got := counts["aws_instance"]; got != 2 {
+		t.Fatalf("wrong number of apply-time ValidateResourceConfig calls with the optimization disabled: got %d, want 2 (for both aws_instance.foo and aws_instance.bar)", got)
+	}
+}
+
 func TestContext2Apply_stop(t *testing.T) {
 	t.Parallel()
 
@@ -1501,6 +1670,56 @@ func testContext2Apply_destroyDependsOnStateOnly(t *testing.T, state *states.Sta
 	}
 }
 
+// Test that a dependency address left over in state from a resource that's
+// since been removed from configuration doesn't survive an apply.
+func TestContext2Apply_prunesStaleDependencies(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+resource "aws_instance" "foo" {
+	ami = "new-ami"
+}
+`,
+	})
+
+	state := states.NewState()
+	root := state.EnsureModule(addrs.RootModuleInstance)
+	root.SetResourceInstanceCurrent(
+		mustResourceInstanceAddr("aws_instance.foo").Resource,
+		&states.ResourceInstanceObjectSrc{
+			Status:    states.ObjectReady,
+			AttrsJSON: []byte(`{"id":"foo","ami":"old-ami"}`),
+			Dependencies: []addrs.ConfigResource{
+				mustConfigResourceAddr("aws_instance.removed"),
+			},
+		},
+		mustProviderConfig(`provider["registry.terraform.io/hashicorp/aws"]`),
+	)
+
+	p := testProvider("aws")
+	p.PlanResourceChangeFn = testDiffFn
+	p.ApplyResourceChangeFn = testApplyFn
+
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("aws"): testProviderFuncFixed(p),
+		},
+	})
+
+	plan, diags := ctx.Plan(m, state, DefaultPlanOpts)
+	assertNoErrors(t, diags)
+
+	newState, diags := ctx.Apply(plan, m, nil)
+	assertNoErrors(t, diags)
+
+	fooState := newState.ResourceInstance(mustResourceInstanceAddr("aws_instance.foo"))
+	if fooState == nil || fooState.Current == nil {
+		t.Fatal("no state recorded for aws_instance.foo")
+	}
+	if len(fooState.Current.Dependencies) != 0 {
+		t.Fatalf("expected stale dependency to be pruned, got: %#v", fooState.Current.Dependencies)
+	}
+}
+
 // Test that destroy ordering is correct with dependencies only
 // in the state within a module (GH-11749)
 func TestContext2Apply_destroyDependsOnStateOnlyModule(t *testing.T) {
@@ -10346,6 +10565,60 @@ func TestContext2Apply_ProviderMeta_plan_setNoSchema(t *testing.T) {
 	}
 }
 
+func TestContext2Apply_ProviderMeta_plan_multipleSchemaVersionsSelectsNewest(t *testing.T) {
+	m := testModule(t, "provider-meta-set")
+	p := testProvider("test")
+	schema := getProviderSchema(p)
+	p.GetProviderSchemaResponse = getProviderSchemaResponseFromProviderSchema(schema)
+	p.GetProviderSchemaResponse.ProviderMetaSchemas = map[int64]providers.Schema{
+		1: {
+			Block: &configschema.Block{
+				Attributes: map[string]*configschema.Attribute{
+					"qux": {Type: cty.String, Optional: true},
+				},
+			},
+		},
+		2: {
+			Block: &configschema.Block{
+				Attributes: map[string]*configschema.Attribute{
+					"baz": {Type: cty.String, Required: true},
+				},
+			},
+		},
+	}
+
+	prcPMs := map[string]cty.Value{}
+	p.PlanResourceChangeFn = func(req providers.PlanResourceChangeRequest) providers.PlanResourceChangeResponse {
+		prcPMs[req.TypeName] = req.ProviderMeta
+		return providers.PlanResourceChangeResponse{
+			PlannedState: req.ProposedNewState,
+		}
+	}
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	_, diags := ctx.Plan(m, states.NewState(), DefaultPlanOpts)
+	assertNoErrors(t, diags)
+
+	pm, ok := prcPMs["test_instance"]
+	if !ok {
+		t.Fatalf("root module PlanResourceChange not called")
+	}
+	if pm.IsNull() {
+		t.Fatalf("null ProviderMeta in root module PlanResourceChange")
+	}
+	// Version 1 has no "baz" attribute, so the fixture's provider_meta
+	// blocks would be rejected if it were selected; a successful plan with
+	// a known "baz" here proves the newer schema was the one Terraform
+	// selected and evaluated the config against, not version 1.
+	if !pm.GetAttr("baz").IsKnown() {
+		t.Fatalf("expected baz to be known, got %#v", pm)
+	}
+}
+
 func TestContext2Apply_ProviderMeta_plan_setInvalid(t *testing.T) {
 	m := testModule(t, "provider-meta-set")
 	p := testProvider("test")
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"log"
 	"os"
 	"reflect"
 	"sort"
@@ -14,12 +15,14 @@ import (
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/google/go-cmp/cmp"
 	"github.com/zclconf/go-cty/cty"
 
 	"github.com/hashicorp/terraform/internal/addrs"
+	"github.com/hashicorp/terraform/internal/configs"
 	"github.com/hashicorp/terraform/internal/configs/configschema"
 	"github.com/hashicorp/terraform/internal/configs/hcl2shim"
 	"github.com/hashicorp/terraform/internal/lang/marks"
@@ -80,6 +83,41 @@ func TestContext2Plan_basic(t *testing.T) {
 
 }
 
+type testPlanTimingProfiler struct {
+	mu        sync.Mutex
+	durations map[string]time.Duration
+}
+
+func (p *testPlanTimingProfiler) RecordPlanDuration(addr addrs.AbsResourceInstance, d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.durations == nil {
+		p.durations = make(map[string]time.Duration)
+	}
+	p.durations[addr.String()] = d
+}
+
+func TestContext2Plan_timingProfiler(t *testing.T) {
+	m := testModule(t, "plan-good")
+	p := testProvider("aws")
+	profiler := &testPlanTimingProfiler{}
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("aws"): testProviderFuncFixed(p),
+		},
+		PlanTimingProfiler: profiler,
+	})
+
+	_, diags := ctx.Plan(m, states.NewState(), DefaultPlanOpts)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Err())
+	}
+
+	if _, ok := profiler.durations["aws_instance.foo"]; !ok {
+		t.Fatalf("expected a recorded plan duration for aws_instance.foo, got %#v", profiler.durations)
+	}
+}
+
 func TestContext2Plan_createBefore_deposed(t *testing.T) {
 	m := testModule(t, "plan-cbd")
 	p := testProvider("aws")
@@ -266,6 +304,31 @@ func TestContext2Plan_emptyDiff(t *testing.T) {
 	}
 }
 
+func TestContext2Plan_nilPlannedState(t *testing.T) {
+	m := testModule(t, "plan-empty")
+	p := testProvider("aws")
+	p.PlanResourceChangeFn = func(req providers.PlanResourceChangeRequest) (resp providers.PlanResourceChangeResponse) {
+		// A real provider can never do this over RPC, but a misconfigured
+		// mock provider can, and we should report it as a provider bug
+		// rather than crashing.
+		return providers.PlanResourceChangeResponse{}
+	}
+
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("aws"): testProviderFuncFixed(p),
+		},
+	})
+
+	_, diags := ctx.Plan(m, states.NewState(), DefaultPlanOpts)
+	if !diags.HasErrors() {
+		t.Fatal("expected error, got none")
+	}
+	if got, want := diags.Err().Error(), "Provider produced invalid plan"; !strings.Contains(got, want) {
+		t.Fatalf("wrong error\ngot: %s\nwant substring: %s", got, want)
+	}
+}
+
 func TestContext2Plan_escapedVar(t *testing.T) {
 	m := testModule(t, "plan-escaped-var")
 	p := testProvider("aws")
@@ -3810,6 +3873,116 @@ func TestContext2Plan_requiresReplace(t *testing.T) {
 	}
 }
 
+// requiresReplaceWithComputedAttr returns a module and provider fixture for
+// a resource whose "v" attribute always forces replacement, and which also
+// has a "computed" attribute the provider fills in during plan. The
+// PlanResourceChangeFn counts how many times it's called, so tests can
+// assert whether the second null-prior replan happened.
+func requiresReplaceWithComputedAttr(t *testing.T, deterministicComputedValues bool) (*configs.Config, *testing_provider.MockProvider, *int) {
+	t.Helper()
+	m := testModule(t, "plan-requires-replace")
+	p := testProvider("test")
+	p.GetProviderSchemaResponse = &providers.GetProviderSchemaResponse{
+		Provider: providers.Schema{
+			Block: &configschema.Block{},
+		},
+		ResourceTypes: map[string]providers.Schema{
+			"test_thing": {
+				Block: &configschema.Block{
+					Attributes: map[string]*configschema.Attribute{
+						"v": {
+							Type:     cty.String,
+							Required: true,
+						},
+						"computed": {
+							Type:     cty.String,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+		ServerCapabilities: providers.ServerCapabilities{
+			DeterministicComputedValues: deterministicComputedValues,
+		},
+	}
+
+	var callCount int
+	p.PlanResourceChangeFn = func(req providers.PlanResourceChangeRequest) providers.PlanResourceChangeResponse {
+		callCount++
+		m := req.ProposedNewState.AsValueMap()
+		m["computed"] = cty.StringVal("fixed-value")
+		return providers.PlanResourceChangeResponse{
+			PlannedState: cty.ObjectVal(m),
+			RequiresReplace: []cty.Path{
+				cty.GetAttrPath("v"),
+			},
+		}
+	}
+
+	return m, p, &callCount
+}
+
+func TestContext2Plan_requiresReplaceSkipsReplanWhenDeterministic(t *testing.T) {
+	m, p, callCount := requiresReplaceWithComputedAttr(t, true)
+
+	state := states.NewState()
+	root := state.EnsureModule(addrs.RootModuleInstance)
+	root.SetResourceInstanceCurrent(
+		mustResourceInstanceAddr("test_thing.foo").Resource,
+		&states.ResourceInstanceObjectSrc{
+			Status:    states.ObjectReady,
+			AttrsJSON: []byte(`{"v":"hello","computed":"old-value"}`),
+		},
+		mustProviderConfig(`provider["registry.terraform.io/hashicorp/test"]`),
+	)
+
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	_, diags := ctx.Plan(m, state, DefaultPlanOpts)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Err())
+	}
+
+	if got, want := *callCount, 1; got != want {
+		t.Fatalf("expected PlanResourceChange to be called %d time(s) when the provider declares deterministic computed values, got %d", want, got)
+	}
+}
+
+func TestContext2Plan_requiresReplaceReplansWithoutDeterministic(t *testing.T) {
+	m, p, callCount := requiresReplaceWithComputedAttr(t, false)
+
+	state := states.NewState()
+	root := state.EnsureModule(addrs.RootModuleInstance)
+	root.SetResourceInstanceCurrent(
+		mustResourceInstanceAddr("test_thing.foo").Resource,
+		&states.ResourceInstanceObjectSrc{
+			Status:    states.ObjectReady,
+			AttrsJSON: []byte(`{"v":"hello","computed":"old-value"}`),
+		},
+		mustProviderConfig(`provider["registry.terraform.io/hashicorp/test"]`),
+	)
+
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	_, diags := ctx.Plan(m, state, DefaultPlanOpts)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Err())
+	}
+
+	if got, want := *callCount, 2; got != want {
+		t.Fatalf("expected PlanResourceChange to be called %d time(s) without the deterministic computed values capability, got %d", want, got)
+	}
+}
+
 func TestContext2Plan_taint(t *testing.T) {
 	m := testModule(t, "plan-taint")
 	p := testProvider("aws")
@@ -6700,6 +6873,130 @@ resource "test_instance" "a" {
 	}
 }
 
+// legacyProviderInconsistentPlan returns a provider and module fixture whose
+// PlanResourceChange response silently rewrites a non-computed attribute,
+// which is inconsistent with the proposed new value and would normally be
+// rejected by objchange.AssertPlanValid.
+func legacyProviderInconsistentPlan(t *testing.T) (*configs.Config, *testing_provider.MockProvider) {
+	t.Helper()
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+resource "test_instance" "a" {
+  data = "foo"
+}
+`,
+	})
+
+	p := testProvider("test")
+	p.PlanResourceChangeFn = func(req providers.PlanResourceChangeRequest) (resp providers.PlanResourceChangeResponse) {
+		m := req.ProposedNewState.AsValueMap()
+		// A well-behaved provider must not alter a non-computed attribute
+		// away from what the config/proposed value said, but some providers
+		// using the legacy SDK do this anyway.
+		m["data"] = cty.StringVal("bar")
+
+		resp.PlannedState = cty.ObjectVal(m)
+		resp.LegacyTypeSystem = true
+		return resp
+	}
+
+	p.GetProviderSchemaResponse = getProviderSchemaResponseFromProviderSchema(&providerSchema{
+		ResourceTypes: map[string]*configschema.Block{
+			"test_instance": {
+				Attributes: map[string]*configschema.Attribute{
+					"id":   {Type: cty.String, Computed: true},
+					"data": {Type: cty.String, Optional: true},
+				},
+			},
+		},
+	})
+
+	return m, p
+}
+
+func TestContext2Plan_legacyProviderInconsistentPlanTolerant(t *testing.T) {
+	m, p := legacyProviderInconsistentPlan(t)
+
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	// With no strict mode requested, the inconsistency is only logged as a
+	// warning and the plan still succeeds.
+	_, diags := ctx.Plan(m, states.NewState(), DefaultPlanOpts)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Err())
+	}
+}
+
+func TestContext2Plan_legacyProviderInconsistentPlanStrict(t *testing.T) {
+	m, p := legacyProviderInconsistentPlan(t)
+
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+		StrictLegacyPlanConsistency: true,
+	})
+
+	_, diags := ctx.Plan(m, states.NewState(), DefaultPlanOpts)
+	if !diags.HasErrors() {
+		t.Fatal("expected error, got none")
+	}
+	if got, want := diags.Err().Error(), "Provider produced invalid plan"; !strings.Contains(got, want) {
+		t.Fatalf("wrong error\ngot: %s\nwant substring: %s", got, want)
+	}
+}
+
+func TestContext2Plan_assertObjectCompatibilityDisabled(t *testing.T) {
+	m, p := legacyProviderInconsistentPlan(t)
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	_, diags := ctx.Plan(m, states.NewState(), DefaultPlanOpts)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Err())
+	}
+
+	if got, dontWant := logs.String(), "produced a planned value"; strings.Contains(got, dontWant) {
+		t.Fatalf("expected no object-compatibility warning, but found one in logs: %s", got)
+	}
+}
+
+func TestContext2Plan_assertObjectCompatibilityEnabled(t *testing.T) {
+	m, p := legacyProviderInconsistentPlan(t)
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+		AssertPlanObjectCompatibility: true,
+	})
+
+	_, diags := ctx.Plan(m, states.NewState(), DefaultPlanOpts)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Err())
+	}
+
+	if got, want := logs.String(), "produced a planned value"; !strings.Contains(got, want) {
+		t.Fatalf("expected an object-compatibility warning in logs, got: %s", got)
+	}
+}
+
 func TestContext2Plan_validateIgnoreAll(t *testing.T) {
 	m := testModuleInline(t, map[string]string{
 		"main.tf": `
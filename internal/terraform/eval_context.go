@@ -5,6 +5,7 @@ package terraform
 
 import (
 	"context"
+	"time"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/zclconf/go-cty/cty"
@@ -205,6 +206,18 @@ type EvalContext interface {
 	// this execution.
 	Overrides() *mocking.Overrides
 
+	// PlanTimingProfiler returns the profiler to report per-resource plan
+	// timings to, or nil if no profiler is configured. Callers must treat a
+	// nil result as "do not instrument" rather than substituting a no-op
+	// implementation, so that profiling imposes no overhead by default.
+	PlanTimingProfiler() PlanTimingProfiler
+
+	// ProviderApplyLimiter returns the limiter to acquire before making an
+	// ApplyResourceChange call, or nil if no per-provider apply concurrency
+	// limit is configured, in which case callers must not attempt to
+	// acquire anything.
+	ProviderApplyLimiter() *ProviderApplyLimiter
+
 	// withScope derives a new EvalContext that has all of the same global
 	// context, but a new evaluation scope.
 	withScope(scope evalContextScope) EvalContext
@@ -212,6 +225,47 @@ type EvalContext interface {
 	// Forget if set to true will cause the plan to forget all resources. This is
 	// only allowed in the context of a destroy plan.
 	Forget() bool
+
+	// SkipRedundantApplyTimeValidation returns true if NodeAbstractResourceInstance.plan
+	// is permitted to skip its ValidateResourceConfig call during apply when
+	// the configuration is fully known and still produces the exact value
+	// that was already validated at plan time. Defaults to false so that
+	// every apply revalidates the final configuration, as Terraform has
+	// always done.
+	SkipRedundantApplyTimeValidation() bool
+
+	// StrictLegacyPlanConsistency returns true if NodeAbstractResourceInstance.plan
+	// should promote an AssertPlanValid failure from a legacy-SDK provider to
+	// an error instead of the usual tolerant [WARN] log line. Defaults to
+	// false so that legacy SDK providers continue to work despite their
+	// imprecise type system mapping; provider authors can opt in to catch
+	// these bugs during development and testing.
+	StrictLegacyPlanConsistency() bool
+
+	// AssertPlanObjectCompatibility returns true if NodeAbstractResourceInstance.plan
+	// should additionally check that a provider's planned value for a
+	// resource instance is compatible with the proposed new value Terraform
+	// built from configuration, logging a [WARN] line on any mismatch.
+	// Defaults to false, since this check is opt-in while it's being proven
+	// safe against real-world providers.
+	AssertPlanObjectCompatibility() bool
+
+	// RefreshTimeout returns the maximum duration a single resource
+	// instance's ReadResource call may run during refresh before it's
+	// aborted with a timeout diagnostic naming the resource. A value of
+	// zero, the default, means refresh waits indefinitely.
+	RefreshTimeout() time.Duration
+
+	// PlanCache returns the cache to consult for a reusable no-op plan
+	// result, or nil if no cache is configured, in which case callers must
+	// not attempt to use one.
+	PlanCache() ResourceInstancePlanCache
+
+	// Config returns the root of the configuration tree for the whole
+	// run, so callers can check whether some previously-recorded address
+	// (such as a dependency read back from state) still corresponds to
+	// something the current configuration declares.
+	Config() *configs.Config
 }
 
 func evalContextForModuleInstance(baseCtx EvalContext, addr addrs.ModuleInstance) EvalContext {
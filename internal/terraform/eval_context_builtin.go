@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"log"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/zclconf/go-cty/cty"
@@ -73,13 +74,42 @@ type BuiltinEvalContext struct {
 	// only allowd in the context of a destroy plan.
 	forget bool
 
-	Hooks                   []Hook
-	InputValue              UIInput
-	ProviderCache           map[string]providers.Interface
-	ProviderFuncCache       map[string]providers.Interface
-	ProviderFuncResults     *providers.FunctionResults
-	ProviderInputConfig     map[string]map[string]cty.Value
-	ProviderLock            *sync.Mutex
+	// skipRedundantApplyTimeValidation is the value returned by
+	// [BuiltinEvalContext.SkipRedundantApplyTimeValidation].
+	skipRedundantApplyTimeValidation bool
+
+	// strictLegacyPlanConsistency is the value returned by
+	// [BuiltinEvalContext.StrictLegacyPlanConsistency].
+	strictLegacyPlanConsistency bool
+
+	// assertPlanObjectCompatibility is the value returned by
+	// [BuiltinEvalContext.AssertPlanObjectCompatibility].
+	assertPlanObjectCompatibility bool
+
+	// refreshTimeout is the value returned by
+	// [BuiltinEvalContext.RefreshTimeout].
+	refreshTimeout time.Duration
+
+	// PlanCacheValue is the value returned by
+	// [BuiltinEvalContext.PlanCache]. It is nil unless the caller has
+	// configured a plan cache in ContextOpts.
+	PlanCacheValue ResourceInstancePlanCache
+
+	Hooks               []Hook
+	InputValue          UIInput
+	ProviderCache       map[string]providers.Interface
+	ProviderFuncCache   map[string]providers.Interface
+	ProviderFuncResults *providers.FunctionResults
+	ProviderInputConfig map[string]map[string]cty.Value
+	ProviderLock        *sync.Mutex
+
+	// ProviderSchemas memoizes the result of ProviderSchema, keyed by
+	// provider address (addrs.Provider.String()), so that graph nodes
+	// sharing this context (and thus this map) don't each re-derive the
+	// same provider's schema. It's shared across all of the scoped
+	// EvalContext values for a given ContextGraphWalker, the same way
+	// ProviderCache is. Guarded by ProviderLock.
+	ProviderSchemas         map[string]providers.ProviderSchema
 	ProvisionerCache        map[string]provisioners.Interface
 	ProvisionerLock         *sync.Mutex
 	ChangesValue            *plans.ChangesSync
@@ -91,6 +121,16 @@ type BuiltinEvalContext struct {
 	InstanceExpanderValue   *instances.Expander
 	MoveResultsValue        refactoring.MoveResults
 	OverrideValues          *mocking.Overrides
+
+	// PlanTimingProfilerValue is the object returned by
+	// [BuiltinEvalContext.PlanTimingProfiler]. It is nil unless the caller
+	// explicitly wants per-resource plan timing instrumentation.
+	PlanTimingProfilerValue PlanTimingProfiler
+
+	// ProviderApplyLimiterValue is the object returned by
+	// [BuiltinEvalContext.ProviderApplyLimiter]. It is nil unless the caller
+	// explicitly wants to cap per-provider apply concurrency.
+	ProviderApplyLimiterValue *ProviderApplyLimiter
 }
 
 // BuiltinEvalContext implements EvalContext
@@ -193,7 +233,25 @@ func (ctx *BuiltinEvalContext) Provider(addr addrs.AbsProviderConfig) providers.
 }
 
 func (ctx *BuiltinEvalContext) ProviderSchema(addr addrs.AbsProviderConfig) (providers.ProviderSchema, error) {
-	return ctx.Plugins.ProviderSchema(addr.Provider)
+	key := addr.Provider.String()
+
+	ctx.ProviderLock.Lock()
+	schema, ok := ctx.ProviderSchemas[key]
+	ctx.ProviderLock.Unlock()
+	if ok {
+		return schema, nil
+	}
+
+	schema, err := ctx.Plugins.ProviderSchema(addr.Provider)
+	if err != nil {
+		return schema, err
+	}
+
+	ctx.ProviderLock.Lock()
+	ctx.ProviderSchemas[key] = schema
+	ctx.ProviderLock.Unlock()
+
+	return schema, nil
 }
 
 func (ctx *BuiltinEvalContext) CloseProvider(addr addrs.AbsProviderConfig) error {
@@ -204,6 +262,10 @@ func (ctx *BuiltinEvalContext) CloseProvider(addr addrs.AbsProviderConfig) error
 	provider := ctx.ProviderCache[key]
 	if provider != nil {
 		delete(ctx.ProviderCache, key)
+		// Drop any memoized schema too, so that the next ProviderSchema
+		// call for this provider fetches it from ctx.Plugins again instead
+		// of reusing the memoized copy.
+		delete(ctx.ProviderSchemas, addr.Provider.String())
 		return provider.Close()
 	}
 
@@ -574,6 +636,14 @@ func (ctx *BuiltinEvalContext) Deferrals() *deferring.Deferred {
 	return ctx.DeferralsValue
 }
 
+func (ctx *BuiltinEvalContext) PlanTimingProfiler() PlanTimingProfiler {
+	return ctx.PlanTimingProfilerValue
+}
+
+func (ctx *BuiltinEvalContext) ProviderApplyLimiter() *ProviderApplyLimiter {
+	return ctx.ProviderApplyLimiterValue
+}
+
 func (ctx *BuiltinEvalContext) Changes() *plans.ChangesSync {
 	return ctx.ChangesValue
 }
@@ -610,6 +680,30 @@ func (ctx *BuiltinEvalContext) Forget() bool {
 	return ctx.forget
 }
 
+func (ctx *BuiltinEvalContext) SkipRedundantApplyTimeValidation() bool {
+	return ctx.skipRedundantApplyTimeValidation
+}
+
+func (ctx *BuiltinEvalContext) StrictLegacyPlanConsistency() bool {
+	return ctx.strictLegacyPlanConsistency
+}
+
+func (ctx *BuiltinEvalContext) AssertPlanObjectCompatibility() bool {
+	return ctx.assertPlanObjectCompatibility
+}
+
+func (ctx *BuiltinEvalContext) RefreshTimeout() time.Duration {
+	return ctx.refreshTimeout
+}
+
+func (ctx *BuiltinEvalContext) PlanCache() ResourceInstancePlanCache {
+	return ctx.PlanCacheValue
+}
+
+func (ctx *BuiltinEvalContext) Config() *configs.Config {
+	return ctx.Evaluator.Config
+}
+
 func (ctx *BuiltinEvalContext) EphemeralResources() *ephemeral.Resources {
 	return ctx.EphemeralResourcesValue
 }
@@ -0,0 +1,172 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package terraform
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hashicorp/terraform/internal/addrs"
+	"github.com/hashicorp/terraform/internal/configs/configschema"
+	"github.com/hashicorp/terraform/internal/providers"
+	testing_provider "github.com/hashicorp/terraform/internal/providers/testing"
+)
+
+// countingSchemaProvider wraps a MockProvider to count how many times
+// GetProviderSchema is actually invoked on it, so tests can assert on
+// memoization behavior elsewhere in Terraform Core.
+type countingSchemaProvider struct {
+	*testing_provider.MockProvider
+	schemaFetches int32
+}
+
+func (p *countingSchemaProvider) GetProviderSchema() providers.GetProviderSchemaResponse {
+	atomic.AddInt32(&p.schemaFetches, 1)
+	return p.MockProvider.GetProviderSchema()
+}
+
+func TestBuiltinEvalContextProviderSchema_memoized(t *testing.T) {
+	var lock sync.Mutex
+
+	addr := addrs.AbsProviderConfig{
+		Module:   addrs.RootModule,
+		Provider: addrs.NewDefaultProvider("test"),
+	}
+	cached := providers.ProviderSchema{
+		ResourceTypes: map[string]providers.Schema{},
+	}
+
+	ctx := &BuiltinEvalContext{
+		ProviderLock:    &lock,
+		ProviderCache:   make(map[string]providers.Interface),
+		ProviderSchemas: map[string]providers.ProviderSchema{addr.Provider.String(): cached},
+	}
+
+	// With the schema already memoized, ProviderSchema must not need to
+	// consult ctx.Plugins at all; leaving it nil proves that, since a
+	// fallthrough to ctx.Plugins.ProviderSchema would panic on a nil
+	// pointer dereference.
+	got, err := ctx.ProviderSchema(addr)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(got, cached) {
+		t.Fatalf("wrong schema returned: got %#v, want %#v", got, cached)
+	}
+}
+
+func TestBuiltinEvalContextCloseProvider_invalidatesSchema(t *testing.T) {
+	var lock sync.Mutex
+
+	testP := &testing_provider.MockProvider{}
+	addr := addrs.AbsProviderConfig{
+		Module:   addrs.RootModule,
+		Provider: addrs.NewDefaultProvider("test"),
+	}
+
+	ctx := &BuiltinEvalContext{
+		ProviderLock:    &lock,
+		ProviderCache:   map[string]providers.Interface{addr.String(): testP},
+		ProviderSchemas: map[string]providers.ProviderSchema{addr.Provider.String(): {}},
+	}
+
+	if err := ctx.CloseProvider(addr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, ok := ctx.ProviderSchemas[addr.Provider.String()]; ok {
+		t.Fatal("expected closing the provider to invalidate its memoized schema")
+	}
+}
+
+// newSynth1085TestProvider builds the counting provider shared by
+// TestBuiltinEvalContextProviderSchema_manyInstances and its benchmark
+// counterpart below.
+func newSynth1085TestProvider() *countingSchemaProvider {
+	return &countingSchemaProvider{
+		MockProvider: &testing_provider.MockProvider{
+			GetProviderSchemaResponse: getProviderSchemaResponseFromProviderSchema(&providerSchema{
+				ResourceTypes: map[string]*configschema.Block{
+					"synth1085_thing": {
+						Attributes: map[string]*configschema.Attribute{
+							"id": {Type: cty.String, Computed: true},
+						},
+					},
+				},
+			}),
+		},
+	}
+}
+
+// TestBuiltinEvalContextProviderSchema_manyInstances simulates many resource
+// instances that all share a single provider configuration, as happens in a
+// large "count"-expanded resource, and confirms that the provider's schema
+// is only actually fetched once no matter how many instances ask for it
+// through the same EvalContext.
+func TestBuiltinEvalContextProviderSchema_manyInstances(t *testing.T) {
+	const instanceCount = 1000
+
+	addr := addrs.AbsProviderConfig{
+		Module:   addrs.RootModule,
+		Provider: addrs.NewDefaultProvider("synth1085"),
+	}
+
+	p := newSynth1085TestProvider()
+
+	var lock sync.Mutex
+	ctx := &BuiltinEvalContext{
+		ProviderLock:    &lock,
+		ProviderSchemas: make(map[string]providers.ProviderSchema),
+		Plugins: newContextPlugins(map[addrs.Provider]providers.Factory{
+			addr.Provider: testProviderFuncFixed(p),
+		}, nil, nil),
+	}
+
+	for n := 0; n < instanceCount; n++ {
+		if _, err := ctx.ProviderSchema(addr); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := atomic.LoadInt32(&p.schemaFetches); got != 1 {
+		t.Fatalf("provider schema was fetched %d times across %d instances; want 1 (the per-context schema cache should have absorbed the rest)", got, instanceCount)
+	}
+}
+
+// BenchmarkBuiltinEvalContextProviderSchema_manyInstances measures the cost
+// of looking up a memoized provider schema across a synthetic 1000-instance
+// graph; see TestBuiltinEvalContextProviderSchema_manyInstances for the
+// accompanying fetch-count correctness check.
+func BenchmarkBuiltinEvalContextProviderSchema_manyInstances(b *testing.B) {
+	const instanceCount = 1000
+
+	addr := addrs.AbsProviderConfig{
+		Module:   addrs.RootModule,
+		Provider: addrs.NewDefaultProvider("synth1085"),
+	}
+
+	p := newSynth1085TestProvider()
+	plugins := newContextPlugins(map[addrs.Provider]providers.Factory{
+		addr.Provider: testProviderFuncFixed(p),
+	}, nil, nil)
+
+	for i := 0; i < b.N; i++ {
+		var lock sync.Mutex
+		ctx := &BuiltinEvalContext{
+			ProviderLock:    &lock,
+			ProviderSchemas: make(map[string]providers.ProviderSchema),
+			Plugins:         plugins,
+		}
+
+		for n := 0; n < instanceCount; n++ {
+			if _, err := ctx.ProviderSchema(addr); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -5,6 +5,7 @@ package terraform
 
 import (
 	"context"
+	"time"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hcldec"
@@ -132,6 +133,12 @@ type MockEvalContext struct {
 	DeferralsCalled bool
 	DeferralsState  *deferring.Deferred
 
+	PlanTimingProfilerCalled bool
+	PlanTimingProfilerValue  PlanTimingProfiler
+
+	ProviderApplyLimiterCalled bool
+	ProviderApplyLimiterValue  *ProviderApplyLimiter
+
 	ChangesCalled  bool
 	ChangesChanges *plans.ChangesSync
 
@@ -161,6 +168,24 @@ type MockEvalContext struct {
 
 	ForgetCalled bool
 	ForgetValues bool
+
+	SkipRedundantApplyTimeValidationCalled bool
+	SkipRedundantApplyTimeValidationValue  bool
+
+	StrictLegacyPlanConsistencyCalled bool
+	StrictLegacyPlanConsistencyValue  bool
+
+	AssertPlanObjectCompatibilityCalled bool
+	AssertPlanObjectCompatibilityValue  bool
+
+	RefreshTimeoutCalled bool
+	RefreshTimeoutValue  time.Duration
+
+	PlanCacheCalled bool
+	PlanCacheValue  ResourceInstancePlanCache
+
+	ConfigCalled bool
+	ConfigValue  *configs.Config
 }
 
 // MockEvalContext implements EvalContext
@@ -424,3 +449,43 @@ func (c *MockEvalContext) Forget() bool {
 	c.ForgetCalled = true
 	return c.ForgetValues
 }
+
+func (c *MockEvalContext) SkipRedundantApplyTimeValidation() bool {
+	c.SkipRedundantApplyTimeValidationCalled = true
+	return c.SkipRedundantApplyTimeValidationValue
+}
+
+func (c *MockEvalContext) StrictLegacyPlanConsistency() bool {
+	c.StrictLegacyPlanConsistencyCalled = true
+	return c.StrictLegacyPlanConsistencyValue
+}
+
+func (c *MockEvalContext) AssertPlanObjectCompatibility() bool {
+	c.AssertPlanObjectCompatibilityCalled = true
+	return c.AssertPlanObjectCompatibilityValue
+}
+
+func (c *MockEvalContext) RefreshTimeout() time.Duration {
+	c.RefreshTimeoutCalled = true
+	return c.RefreshTimeoutValue
+}
+
+func (c *MockEvalContext) PlanCache() ResourceInstancePlanCache {
+	c.PlanCacheCalled = true
+	return c.PlanCacheValue
+}
+
+func (c *MockEvalContext) Config() *configs.Config {
+	c.ConfigCalled = true
+	return c.ConfigValue
+}
+
+func (c *MockEvalContext) PlanTimingProfiler() PlanTimingProfiler {
+	c.PlanTimingProfilerCalled = true
+	return c.PlanTimingProfilerValue
+}
+
+func (c *MockEvalContext) ProviderApplyLimiter() *ProviderApplyLimiter {
+	c.ProviderApplyLimiterCalled = true
+	return c.ProviderApplyLimiterValue
+}
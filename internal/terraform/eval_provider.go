@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform/internal/addrs"
 	"github.com/hashicorp/terraform/internal/configs"
 	"github.com/hashicorp/terraform/internal/providers"
+	"github.com/hashicorp/terraform/internal/tfdiags"
 )
 
 func buildProviderConfig(ctx EvalContext, addr addrs.AbsProviderConfig, config *configs.Provider) hcl.Body {
@@ -60,3 +61,24 @@ func getProvider(ctx EvalContext, addr addrs.AbsProviderConfig) (providers.Inter
 	}
 	return provider, schema, nil
 }
+
+// getProviderDiags wraps getProvider's raw error, if any, in a tfdiags
+// diagnostic that names both the resource instance that needed the provider
+// and the resolved provider address, so a failure to initialize a provider
+// during plan, refresh, or state writes can be traced back to the specific
+// resource and provider involved.
+func getProviderDiags(ctx EvalContext, resourceAddr addrs.AbsResourceInstance, addr addrs.AbsProviderConfig) (providers.Interface, providers.ProviderSchema, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+	provider, schema, err := getProvider(ctx, addr)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to initialize provider",
+			fmt.Sprintf(
+				"Terraform could not initialize the provider %q required by %s: %s.",
+				addr, resourceAddr, tfdiags.FormatError(err),
+			),
+		))
+	}
+	return provider, schema, diags
+}
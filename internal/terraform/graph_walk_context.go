@@ -114,31 +114,39 @@ func (w *ContextGraphWalker) EvalContext() EvalContext {
 	}
 
 	ctx := &BuiltinEvalContext{
-		StopContext:             w.StopContext,
-		Hooks:                   w.Context.hooks,
-		InputValue:              w.Context.uiInput,
-		EphemeralResourcesValue: w.EphemeralResources,
-		InstanceExpanderValue:   w.InstanceExpander,
-		Plugins:                 w.Context.plugins,
-		ExternalProviderConfigs: w.ExternalProviderConfigs,
-		MoveResultsValue:        w.MoveResults,
-		ProviderCache:           w.providerCache,
-		ProviderFuncCache:       w.providerFuncCache,
-		ProviderFuncResults:     w.providerFuncResults,
-		ProviderInputConfig:     w.Context.providerInputConfig,
-		ProviderLock:            &w.providerLock,
-		ProvisionerCache:        w.provisionerCache,
-		ProvisionerLock:         &w.provisionerLock,
-		ChangesValue:            w.Changes,
-		ChecksValue:             w.Checks,
-		NamedValuesValue:        w.NamedValues,
-		DeferralsValue:          w.Deferrals,
-		StateValue:              w.State,
-		RefreshStateValue:       w.RefreshState,
-		PrevRunStateValue:       w.PrevRunState,
-		Evaluator:               evaluator,
-		OverrideValues:          w.Overrides,
-		forget:                  w.Forget,
+		StopContext:                      w.StopContext,
+		Hooks:                            w.Context.hooks,
+		InputValue:                       w.Context.uiInput,
+		EphemeralResourcesValue:          w.EphemeralResources,
+		InstanceExpanderValue:            w.InstanceExpander,
+		Plugins:                          w.Context.plugins,
+		ExternalProviderConfigs:          w.ExternalProviderConfigs,
+		MoveResultsValue:                 w.MoveResults,
+		ProviderCache:                    w.providerCache,
+		ProviderSchemas:                  w.providerSchemas,
+		ProviderFuncCache:                w.providerFuncCache,
+		ProviderFuncResults:              w.providerFuncResults,
+		ProviderInputConfig:              w.Context.providerInputConfig,
+		ProviderLock:                     &w.providerLock,
+		ProvisionerCache:                 w.provisionerCache,
+		ProvisionerLock:                  &w.provisionerLock,
+		ChangesValue:                     w.Changes,
+		ChecksValue:                      w.Checks,
+		NamedValuesValue:                 w.NamedValues,
+		DeferralsValue:                   w.Deferrals,
+		StateValue:                       w.State,
+		RefreshStateValue:                w.RefreshState,
+		PrevRunStateValue:                w.PrevRunState,
+		Evaluator:                        evaluator,
+		OverrideValues:                   w.Overrides,
+		forget:                           w.Forget,
+		PlanTimingProfilerValue:          w.Context.planTimingProfiler,
+		ProviderApplyLimiterValue:        w.Context.providerApplyLimiter,
+		PlanCacheValue:                   w.Context.planCache,
+		skipRedundantApplyTimeValidation: w.Context.skipRedundantApplyTimeValidation,
+		strictLegacyPlanConsistency:      w.Context.strictLegacyPlanConsistency,
+		assertPlanObjectCompatibility:    w.Context.assertPlanObjectCompatibility,
+		refreshTimeout:                   w.Context.refreshTimeout,
 	}
 
 	return ctx
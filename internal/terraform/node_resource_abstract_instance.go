@@ -6,7 +6,9 @@ package terraform
 import (
 	"fmt"
 	"log"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/hcl/v2"
 	tfaddr "github.com/hashicorp/terraform-registry-address"
@@ -134,6 +136,45 @@ func (n *NodeAbstractResourceInstance) StateDependencies() []addrs.ConfigResourc
 	return n.Dependencies
 }
 
+// pruneStaleDependencies removes any dependency addresses that no longer
+// correspond to a resource in the given configuration and have no
+// remaining resource in the given state, returning the filtered list.
+//
+// A dependency address that's gone from configuration but still has a
+// resource in state is not stale: it's a resource orphaned for destruction
+// this round, and destroy ordering still needs to see it. Only an address
+// that's absent from both config and state is genuinely stale, carried
+// over from a resource that was already removed from the configuration
+// and destroyed in some earlier round. If left unpruned, those stale
+// addresses would be written back out to state by writeResourceInstanceState
+// and would cause future graph walks to chase references to resources that
+// no longer exist.
+func (n *NodeAbstractResourceInstance) pruneStaleDependencies(config *configs.Config, state *states.SyncState, deps []addrs.ConfigResource) []addrs.ConfigResource {
+	if config == nil {
+		return deps
+	}
+
+	var pruned []addrs.ConfigResource
+	for _, dep := range deps {
+		if config.TargetExists(dep) || stateHasConfigResource(state, dep) {
+			pruned = append(pruned, dep)
+		}
+	}
+	return pruned
+}
+
+// stateHasConfigResource returns true if the given state has at least one
+// resource, in any instance of the dependency's module, matching the given
+// configuration address.
+func stateHasConfigResource(state *states.SyncState, addr addrs.ConfigResource) bool {
+	if state == nil {
+		return false
+	}
+	s := state.Lock()
+	defer state.Unlock()
+	return len(s.Resources(addr)) > 0
+}
+
 // GraphNodeResourceInstance
 func (n *NodeAbstractResourceInstance) ResourceInstanceAddr() addrs.AbsResourceInstance {
 	return n.Addr
@@ -285,9 +326,9 @@ func (n *NodeAbstractResourceInstance) writeResourceInstanceStateDeposed(ctx Eva
 // objects you are intending to write.
 func (n *NodeAbstractResourceInstance) writeResourceInstanceStateImpl(ctx EvalContext, deposedKey states.DeposedKey, obj *states.ResourceInstanceObject, targetState phaseState) error {
 	absAddr := n.Addr
-	_, providerSchema, err := getProvider(ctx, n.ResolvedProvider)
-	if err != nil {
-		return err
+	_, providerSchema, providerDiags := getProviderDiags(ctx, n.Addr, n.ResolvedProvider)
+	if providerDiags.HasErrors() {
+		return providerDiags.Err()
 	}
 	logFuncName := "NodeAbstractResouceInstance.writeResourceInstanceState"
 	if deposedKey == states.NotDeposed {
@@ -588,9 +629,10 @@ func (n *NodeAbstractResourceInstance) refresh(ctx EvalContext, deposedKey state
 	} else {
 		log.Printf("[TRACE] NodeAbstractResourceInstance.refresh for %s (deposed object %s)", absAddr, deposedKey)
 	}
-	provider, providerSchema, err := getProvider(ctx, n.ResolvedProvider)
-	if err != nil {
-		return state, deferred, diags.Append(err)
+	provider, providerSchema, providerDiags := getProviderDiags(ctx, n.Addr, n.ResolvedProvider)
+	diags = diags.Append(providerDiags)
+	if diags.HasErrors() {
+		return state, deferred, diags
 	}
 	// If we have no state, we don't do any refreshing
 	if state == nil {
@@ -634,7 +676,7 @@ func (n *NodeAbstractResourceInstance) refresh(ctx EvalContext, deposedKey state
 			NewState: priorVal,
 		}
 	} else {
-		resp = provider.ReadResource(providers.ReadResourceRequest{
+		req := providers.ReadResourceRequest{
 			TypeName:     n.Addr.Resource.Resource.Type,
 			PriorState:   priorVal,
 			Private:      state.Private,
@@ -643,7 +685,18 @@ func (n *NodeAbstractResourceInstance) refresh(ctx EvalContext, deposedKey state
 				DeferralAllowed:            deferralAllowed,
 				WriteOnlyAttributesAllowed: true,
 			},
-		})
+		}
+
+		if timeout := ctx.RefreshTimeout(); timeout > 0 {
+			var timeoutDiags tfdiags.Diagnostics
+			resp, timeoutDiags = n.readResourceWithTimeout(provider, req, timeout)
+			diags = diags.Append(timeoutDiags)
+			if diags.HasErrors() {
+				return state, deferred, diags
+			}
+		} else {
+			resp = provider.ReadResource(req)
+		}
 
 		// If we don't support deferrals, but the provider reports a deferral and does not
 		// emit any error level diagnostics, we should emit an error.
@@ -739,6 +792,39 @@ func (n *NodeAbstractResourceInstance) refresh(ctx EvalContext, deposedKey state
 	return ret, deferred, diags
 }
 
+// readResourceWithTimeout calls provider.ReadResource, aborting with a
+// timeout diagnostic naming the resource if the call doesn't complete
+// within the given duration.
+//
+// The provider call itself cannot be canceled once started, since the
+// providers.Interface method doesn't accept a context, so a provider that
+// ignores the timeout will continue running in the background after this
+// method returns. This still protects the rest of the graph walk from
+// blocking indefinitely on a single unresponsive provider.
+func (n *NodeAbstractResourceInstance) readResourceWithTimeout(provider providers.Interface, req providers.ReadResourceRequest, timeout time.Duration) (providers.ReadResourceResponse, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	respCh := make(chan providers.ReadResourceResponse, 1)
+	go func() {
+		respCh <- provider.ReadResource(req)
+	}()
+
+	select {
+	case resp := <-respCh:
+		return resp, diags
+	case <-time.After(timeout):
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Timeout while refreshing resource",
+			fmt.Sprintf(
+				"Refreshing %s did not complete after %s, so Terraform stopped waiting for it. This may be caused by a slow or unresponsive provider.",
+				n.Addr, timeout,
+			),
+		))
+		return providers.ReadResourceResponse{}, diags
+	}
+}
+
 func (n *NodeAbstractResourceInstance) plan(
 	ctx EvalContext,
 	plannedChange *plans.ResourceInstanceChange,
@@ -753,9 +839,10 @@ func (n *NodeAbstractResourceInstance) plan(
 	resource := n.Addr.Resource.Resource
 	deferralAllowed := ctx.Deferrals().DeferralAllowed()
 
-	provider, providerSchema, err := getProvider(ctx, n.ResolvedProvider)
-	if err != nil {
-		return nil, nil, deferred, keyData, diags.Append(err)
+	provider, providerSchema, providerDiags := getProviderDiags(ctx, n.Addr, n.ResolvedProvider)
+	diags = diags.Append(providerDiags)
+	if diags.HasErrors() {
+		return nil, nil, deferred, keyData, diags
 	}
 
 	schema, _ := providerSchema.SchemaForResourceAddr(resource)
@@ -849,7 +936,6 @@ func (n *NodeAbstractResourceInstance) plan(
 		priorVal = cty.NullVal(schema.ImpliedType())
 	}
 
-	log.Printf("[TRACE] Re-validating config for %q", n.Addr)
 	// Allow the provider to validate the final set of values.  The config was
 	// statically validated early on, but there may have been unknown values
 	// which the provider could not validate at the time.
@@ -861,18 +947,44 @@ func (n *NodeAbstractResourceInstance) plan(
 	// we must unmark and use the original config, since the ignore_changes
 	// handling below needs access to the marks.
 	unmarkedConfigVal, _ := origConfigVal.UnmarkDeep()
-	validateResp := provider.ValidateResourceConfig(
-		providers.ValidateResourceConfigRequest{
-			TypeName: n.Addr.Resource.Resource.Type,
-			Config:   unmarkedConfigVal,
-			ClientCapabilities: providers.ClientCapabilities{
-				WriteOnlyAttributesAllowed: true,
+
+	skipValidate := false
+	if plannedChange != nil && ctx.SkipRedundantApplyTimeValidation() && unmarkedConfigVal.IsWhollyKnown() {
+		// We're re-running plan() during apply to confirm the change is
+		// still consistent with what was planned. The provider already
+		// validated this resource's configuration once during plan, so if
+		// every config-controlled (non-computed) attribute still has the
+		// exact value it had then, calling ValidateResourceConfig again
+		// would just repeat work the provider already did. We ignore
+		// computed attributes here since the provider, not the config,
+		// decides their planned values.
+		configControlled, _ := cty.Transform(plannedChange.After, func(path cty.Path, v cty.Value) (cty.Value, error) {
+			attr := schema.AttributeByPath(path)
+			if attr != nil && attr.Computed {
+				return cty.NullVal(v.Type()), nil
+			}
+			return v, nil
+		})
+		skipValidate = unmarkedConfigVal.RawEquals(configControlled)
+	}
+
+	if skipValidate {
+		log.Printf("[TRACE] Skipping re-validation of config for %q: config is unchanged and fully known since plan", n.Addr)
+	} else {
+		log.Printf("[TRACE] Re-validating config for %q", n.Addr)
+		validateResp := provider.ValidateResourceConfig(
+			providers.ValidateResourceConfigRequest{
+				TypeName: n.Addr.Resource.Resource.Type,
+				Config:   unmarkedConfigVal,
+				ClientCapabilities: providers.ClientCapabilities{
+					WriteOnlyAttributesAllowed: true,
+				},
 			},
-		},
-	)
-	diags = diags.Append(validateResp.Diagnostics.InConfigBody(config.Config, n.Addr.String()))
-	if diags.HasErrors() {
-		return nil, nil, deferred, keyData, diags
+		)
+		diags = diags.Append(validateResp.Diagnostics.InConfigBody(config.Config, n.Addr.String()))
+		if diags.HasErrors() {
+			return nil, nil, deferred, keyData, diags
+		}
 	}
 
 	// ignore_changes is meant to only apply to the configuration, so it must
@@ -904,8 +1016,47 @@ func (n *NodeAbstractResourceInstance) plan(
 		return nil, nil, deferred, keyData, diags
 	}
 
+	// A resource instance is eligible for the plan cache only when its
+	// provider promises deterministic planning, there's an actual prior
+	// object to compare against (so a cache hit can only ever mean "no
+	// change", never "create"), nothing else is already forcing a
+	// different outcome that a cached no-op result wouldn't reflect, and
+	// the prior value and configuration are wholly known. Unknown values
+	// are routine here (an attribute referencing another resource's
+	// not-yet-known computed output), but they can't be hashed, and two
+	// plans with different unknowns could easily resolve differently.
+	var cacheKey ResourceInstancePlanCacheKey
+	cacheEligible := n.override == nil && !priorVal.IsNull() && len(forceReplace) == 0 &&
+		providerSchema.ServerCapabilities.DeterministicPlanning && ctx.PlanCache() != nil &&
+		unmarkedPriorVal.IsWhollyKnown() && unmarkedConfigVal.IsWhollyKnown()
+	if cacheEligible {
+		priorHash, priorOk := hashPlanCacheValue(unmarkedPriorVal)
+		configHash, configOk := hashPlanCacheValue(unmarkedConfigVal)
+		if priorOk && configOk {
+			cacheKey = ResourceInstancePlanCacheKey{
+				Addr:       n.Addr,
+				PriorHash:  priorHash,
+				ConfigHash: configHash,
+			}
+		} else {
+			cacheEligible = false
+		}
+	}
+
 	var resp providers.PlanResourceChangeResponse
-	if n.override != nil {
+	var cacheHit bool
+	if cacheEligible {
+		if cached, ok := ctx.PlanCache().GetNoOpPlan(cacheKey); ok {
+			resp = providers.PlanResourceChangeResponse{PlannedState: cached}
+			cacheHit = true
+		}
+	}
+
+	if cacheHit {
+		// The cached result stands in for a call to PlanResourceChange; the
+		// rest of this function validates and uses it exactly as it would
+		// a freshly computed response.
+	} else if n.override != nil {
 		// Then we have an override to apply for this change. But, overrides
 		// only matter when we are creating a resource for the first time as we
 		// only apply computed values.
@@ -925,6 +1076,11 @@ func (n *NodeAbstractResourceInstance) plan(
 			}
 		}
 	} else {
+		profiler := ctx.PlanTimingProfiler()
+		var start time.Time
+		if profiler != nil {
+			start = time.Now()
+		}
 		resp = provider.PlanResourceChange(providers.PlanResourceChangeRequest{
 			TypeName:         n.Addr.Resource.Resource.Type,
 			Config:           unmarkedConfigVal,
@@ -937,6 +1093,9 @@ func (n *NodeAbstractResourceInstance) plan(
 				WriteOnlyAttributesAllowed: true,
 			},
 		})
+		if profiler != nil {
+			profiler.RecordPlanDuration(n.Addr, time.Since(start))
+		}
 		// If we don't support deferrals, but the provider reports a deferral and does not
 		// emit any error level diagnostics, we should emit an error.
 		if resp.Deferred != nil && !deferralAllowed && !resp.Diagnostics.HasErrors() {
@@ -960,10 +1119,20 @@ func (n *NodeAbstractResourceInstance) plan(
 	// change.
 	if deferred == nil {
 		if plannedNewVal == cty.NilVal {
-			// Should never happen. Since real-world providers return via RPC a nil
-			// is always a bug in the client-side stub. This is more likely caused
-			// by an incompletely-configured mock provider in tests, though.
-			panic(fmt.Sprintf("PlanResourceChange of %s produced nil value", n.Addr))
+			// A provider returning a totally absent value (as opposed to a
+			// null value of the expected type) from PlanResourceChange is
+			// always a bug in the provider, though in practice this is most
+			// often seen from an incompletely-configured mock provider in
+			// tests.
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Provider produced invalid plan",
+				fmt.Sprintf(
+					"Provider %q produced a nil planned value for %s.\n\nThis is a bug in the provider, which should be reported in the provider's own issue tracker.",
+					n.ResolvedProvider.Provider, n.Addr,
+				),
+			))
+			return nil, nil, deferred, keyData, diags
 		}
 
 		// Providers are supposed to return null values for all write-only attributes
@@ -994,7 +1163,7 @@ func (n *NodeAbstractResourceInstance) plan(
 		}
 
 		if errs := objchange.AssertPlanValid(schema, unmarkedPriorVal, unmarkedConfigVal, plannedNewVal); len(errs) > 0 {
-			if resp.LegacyTypeSystem {
+			if resp.LegacyTypeSystem && !ctx.StrictLegacyPlanConsistency() {
 				// The shimming of the old type system in the legacy SDK is not precise
 				// enough to pass this consistency check, so we'll give it a pass here,
 				// but we will generate a warning about it so that we are more likely
@@ -1023,6 +1192,20 @@ func (n *NodeAbstractResourceInstance) plan(
 				return nil, nil, deferred, keyData, diags
 			}
 		}
+
+		if ctx.AssertPlanObjectCompatibility() {
+			if errs := objchange.AssertObjectCompatible(schema, proposedNewVal, plannedNewVal); len(errs) > 0 {
+				var buf strings.Builder
+				fmt.Fprintf(&buf,
+					"[WARN] Provider %q produced a planned value for %s that is not compatible with the proposed new value Terraform built from the configuration:",
+					n.ResolvedProvider.Provider, n.Addr,
+				)
+				for _, err := range errs {
+					fmt.Fprintf(&buf, "\n      - %s", tfdiags.FormatError(err))
+				}
+				log.Print(buf.String())
+			}
+		}
 	}
 
 	if resp.LegacyTypeSystem {
@@ -1090,75 +1273,84 @@ func (n *NodeAbstractResourceInstance) plan(
 		// create a new proposed value from the null state and the config
 		proposedNewVal = objchange.ProposedNew(schema, nullPriorVal, unmarkedConfigVal)
 
-		if n.override != nil {
-			// In this case, we are always creating the resource so we don't
-			// do any validation, and just call out to the mocking library.
-			override, overrideDiags := mocking.PlanComputedValuesForResource(proposedNewVal, schema)
-			resp = providers.PlanResourceChangeResponse{
-				PlannedState: override,
-				Diagnostics:  overrideDiags,
-			}
-		} else {
-			resp = provider.PlanResourceChange(providers.PlanResourceChangeRequest{
-				TypeName:         n.Addr.Resource.Resource.Type,
-				Config:           unmarkedConfigVal,
-				PriorState:       nullPriorVal,
-				ProposedNewState: proposedNewVal,
-				PriorPrivate:     plannedPrivate,
-				ProviderMeta:     metaConfigVal,
-				ClientCapabilities: providers.ClientCapabilities{
-					DeferralAllowed:            deferralAllowed,
-					WriteOnlyAttributesAllowed: true,
-				},
-			})
+		// Providers that declare DeterministicComputedValues promise that
+		// their computed values don't depend on the prior state, so the
+		// plannedNewVal we already got back from the first PlanResourceChange
+		// call above is just as valid for this null-prior replan, and we can
+		// skip the second round-trip to the provider entirely.
+		skipReplan := n.override == nil && providerSchema.ServerCapabilities.DeterministicComputedValues
+
+		if !skipReplan {
+			if n.override != nil {
+				// In this case, we are always creating the resource so we don't
+				// do any validation, and just call out to the mocking library.
+				override, overrideDiags := mocking.PlanComputedValuesForResource(proposedNewVal, schema)
+				resp = providers.PlanResourceChangeResponse{
+					PlannedState: override,
+					Diagnostics:  overrideDiags,
+				}
+			} else {
+				resp = provider.PlanResourceChange(providers.PlanResourceChangeRequest{
+					TypeName:         n.Addr.Resource.Resource.Type,
+					Config:           unmarkedConfigVal,
+					PriorState:       nullPriorVal,
+					ProposedNewState: proposedNewVal,
+					PriorPrivate:     plannedPrivate,
+					ProviderMeta:     metaConfigVal,
+					ClientCapabilities: providers.ClientCapabilities{
+						DeferralAllowed:            deferralAllowed,
+						WriteOnlyAttributesAllowed: true,
+					},
+				})
 
-			// If we don't support deferrals, but the provider reports a deferral and does not
-			// emit any error level diagnostics, we should emit an error.
-			if resp.Deferred != nil && !deferralAllowed && !resp.Diagnostics.HasErrors() {
-				diags = diags.Append(deferring.UnexpectedProviderDeferralDiagnostic(n.Addr))
+				// If we don't support deferrals, but the provider reports a deferral and does not
+				// emit any error level diagnostics, we should emit an error.
+				if resp.Deferred != nil && !deferralAllowed && !resp.Diagnostics.HasErrors() {
+					diags = diags.Append(deferring.UnexpectedProviderDeferralDiagnostic(n.Addr))
+				}
+			}
+			// We need to tread carefully here, since if there are any warnings
+			// in here they probably also came out of our previous call to
+			// PlanResourceChange above, and so we don't want to repeat them.
+			// Consequently, we break from the usual pattern here and only
+			// append these new diagnostics if there's at least one error inside.
+			if resp.Diagnostics.HasErrors() {
+				diags = diags.Append(resp.Diagnostics.InConfigBody(config.Config, n.Addr.String()))
+				return nil, nil, deferred, keyData, diags
 			}
-		}
-		// We need to tread carefully here, since if there are any warnings
-		// in here they probably also came out of our previous call to
-		// PlanResourceChange above, and so we don't want to repeat them.
-		// Consequently, we break from the usual pattern here and only
-		// append these new diagnostics if there's at least one error inside.
-		if resp.Diagnostics.HasErrors() {
-			diags = diags.Append(resp.Diagnostics.InConfigBody(config.Config, n.Addr.String()))
-			return nil, nil, deferred, keyData, diags
-		}
 
-		if deferred == nil && resp.Deferred != nil {
-			deferred = resp.Deferred
-		}
+			if deferred == nil && resp.Deferred != nil {
+				deferred = resp.Deferred
+			}
 
-		plannedNewVal = resp.PlannedState
-		plannedPrivate = resp.PlannedPrivate
+			plannedNewVal = resp.PlannedState
+			plannedPrivate = resp.PlannedPrivate
 
-		if len(nonEphemeralMarks) > 0 {
-			plannedNewVal = plannedNewVal.MarkWithPaths(nonEphemeralMarks)
-		}
+			if len(nonEphemeralMarks) > 0 {
+				plannedNewVal = plannedNewVal.MarkWithPaths(nonEphemeralMarks)
+			}
 
-		for _, err := range plannedNewVal.Type().TestConformance(schema.ImpliedType()) {
-			diags = diags.Append(tfdiags.Sourceless(
-				tfdiags.Error,
-				"Provider produced invalid plan",
-				fmt.Sprintf(
-					"Provider %q planned an invalid value for %s%s.\n\nThis is a bug in the provider, which should be reported in the provider's own issue tracker.",
-					n.ResolvedProvider.Provider, n.Addr, tfdiags.FormatError(err),
-				),
-			))
-		}
-		if diags.HasErrors() {
-			return nil, nil, deferred, keyData, diags
-		}
+			for _, err := range plannedNewVal.Type().TestConformance(schema.ImpliedType()) {
+				diags = diags.Append(tfdiags.Sourceless(
+					tfdiags.Error,
+					"Provider produced invalid plan",
+					fmt.Sprintf(
+						"Provider %q planned an invalid value for %s%s.\n\nThis is a bug in the provider, which should be reported in the provider's own issue tracker.",
+						n.ResolvedProvider.Provider, n.Addr, tfdiags.FormatError(err),
+					),
+				))
+			}
+			if diags.HasErrors() {
+				return nil, nil, deferred, keyData, diags
+			}
 
-		// Providers are supposed to return null values for all write-only attributes
-		writeOnlyDiags := ephemeral.ValidateWriteOnlyAttributes(plannedNewVal, schema, n.ResolvedProvider, n.Addr)
-		diags = diags.Append(writeOnlyDiags)
+			// Providers are supposed to return null values for all write-only attributes
+			writeOnlyDiags := ephemeral.ValidateWriteOnlyAttributes(plannedNewVal, schema, n.ResolvedProvider, n.Addr)
+			diags = diags.Append(writeOnlyDiags)
 
-		if writeOnlyDiags.HasErrors() {
-			return nil, nil, deferred, keyData, diags
+			if writeOnlyDiags.HasErrors() {
+				return nil, nil, deferred, keyData, diags
+			}
 		}
 	}
 
@@ -1210,6 +1402,14 @@ func (n *NodeAbstractResourceInstance) plan(
 		return nil, nil, deferred, keyData, diags
 	}
 
+	// Record a fresh no-op result in the plan cache so that a later call
+	// with the same prior state and configuration can be served from the
+	// cache instead of calling the provider again. There's no need to
+	// re-store a result we just served from the cache.
+	if cacheEligible && !cacheHit && action == plans.NoOp {
+		ctx.PlanCache().PutNoOpPlan(cacheKey, plannedNewVal)
+	}
+
 	// Update our return plan
 	plan := &plans.ResourceInstanceChange{
 		Addr:         n.Addr,
@@ -1245,6 +1445,32 @@ func (n *NodeAbstractResourceInstance) plan(
 	return plan, state, deferred, keyData, diags
 }
 
+// diffPreview computes what plan would produce for this resource instance,
+// without writing the result to the plan's change set or to state, and
+// without firing any of the progress hooks a real plan would use. It's
+// intended for downstream tooling that wants a lightweight preview of what
+// an apply would do without committing to it via writeChange.
+func (n *NodeAbstractResourceInstance) diffPreview(
+	ctx EvalContext,
+	currentState *states.ResourceInstanceObject,
+	createBeforeDestroy bool,
+	forceReplace []addrs.AbsResourceInstance,
+) (*plans.ResourceInstanceChange, tfdiags.Diagnostics) {
+	change, _, _, _, diags := n.plan(diffPreviewEvalContext{ctx}, nil, currentState, createBeforeDestroy, forceReplace)
+	return change, diags
+}
+
+// diffPreviewEvalContext wraps an EvalContext to suppress hook notifications,
+// so that diffPreview can reuse plan() without reporting the same progress a
+// real plan would.
+type diffPreviewEvalContext struct {
+	EvalContext
+}
+
+func (diffPreviewEvalContext) Hook(fn func(Hook) (HookAction, error)) error {
+	return nil
+}
+
 func (n *NodeAbstractResource) processIgnoreChanges(prior, config cty.Value, schema *configschema.Block) (cty.Value, tfdiags.Diagnostics) {
 	// ignore_changes only applies when an object already exists, since we
 	// can't ignore changes to a thing we've not created yet.
@@ -1290,10 +1516,58 @@ func (n *NodeAbstractResource) processIgnoreChanges(prior, config cty.Value, sch
 	}
 
 	ret, diags := processIgnoreChangesIndividual(prior, config, ignoreChanges)
+	diags = diags.Append(warnForIgnoreChangesOnRequiredAttrs(prior, config, ignoreChanges, schema))
 
 	return ret, diags
 }
 
+// warnForIgnoreChangesOnRequiredAttrs returns a warning diagnostic for each
+// ignore_changes path that targets a Required (and therefore non-computed)
+// attribute whose configured value differs from the prior state. Ignoring a
+// required attribute can silently mask a configuration error that the user
+// would otherwise see as a plan diff, so we warn without blocking the plan,
+// since existing configurations may already be relying on this behavior.
+//
+// schema is nil when processIgnoreChanges is being used to revert changes a
+// legacy provider imposed on an "ignore all" plan; in that case there is no
+// per-attribute ignore_changes list to check, so this is a no-op.
+func warnForIgnoreChangesOnRequiredAttrs(prior, config cty.Value, ignoreChanges []cty.Path, schema *configschema.Block) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+	if schema == nil {
+		return diags
+	}
+
+	for _, icPath := range ignoreChanges {
+		attr := schema.AttributeByPath(icPath)
+		if attr == nil || !attr.Required {
+			continue
+		}
+
+		p, err := icPath.Apply(prior)
+		if err != nil {
+			continue
+		}
+		c, err := icPath.Apply(config)
+		if err != nil {
+			continue
+		}
+		if p.RawEquals(c) {
+			continue
+		}
+
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Warning,
+			"Required attribute in ignore_changes",
+			fmt.Sprintf(
+				"The attribute at %s is ignored by ignore_changes, but it is a required attribute with a new configuration value. Ignoring changes to a required attribute can mask configuration errors that would otherwise show up as a plan diff.",
+				tfdiags.FormatCtyPath(icPath),
+			),
+		))
+	}
+
+	return diags
+}
+
 // Convert the hcl.Traversal values we get form the configuration to the
 // cty.Path values we need to operate on the cty.Values
 func traversalsToPaths(traversals []hcl.Traversal) []cty.Path {
@@ -1649,8 +1923,11 @@ func (n *NodeAbstractResourceInstance) providerMetas(ctx EvalContext) (cty.Value
 	}
 	if n.ProviderMetas != nil {
 		if m, ok := n.ProviderMetas[n.ResolvedProvider.Provider]; ok && m != nil {
-			// if the provider doesn't support this feature, throw an error
-			if providerSchema.ProviderMeta.Block == nil {
+			// Negotiate which provider_meta schema version to evaluate the
+			// config against; if the provider doesn't support this feature
+			// at all, this returns a clear error instead of a schema.
+			metaSchema, metaSchemaErr := providerSchema.SelectProviderMetaSchema()
+			if metaSchemaErr != nil {
 				diags = diags.Append(&hcl.Diagnostic{
 					Severity: hcl.DiagError,
 					Summary:  fmt.Sprintf("Provider %s doesn't support provider_meta", n.ResolvedProvider.Provider.String()),
@@ -1659,7 +1936,7 @@ func (n *NodeAbstractResourceInstance) providerMetas(ctx EvalContext) (cty.Value
 				})
 			} else {
 				var configDiags tfdiags.Diagnostics
-				metaConfigVal, _, configDiags = ctx.EvaluateBlock(m.Config, providerSchema.ProviderMeta.Block, nil, EvalDataForNoInstanceKey)
+				metaConfigVal, _, configDiags = ctx.EvaluateBlock(m.Config, metaSchema.Block, nil, EvalDataForNoInstanceKey)
 				diags = diags.Append(configDiags)
 			}
 		}
@@ -2513,6 +2790,11 @@ func (n *NodeAbstractResourceInstance) apply(
 			}
 		}
 	} else {
+		limiter := ctx.ProviderApplyLimiter()
+		if limiter != nil {
+			limiter.Acquire(n.ResolvedProvider.Provider)
+		}
+
 		resp = provider.ApplyResourceChange(providers.ApplyResourceChangeRequest{
 			TypeName:       n.Addr.Resource.Resource.Type,
 			PriorState:     unmarkedBefore,
@@ -2521,6 +2803,10 @@ func (n *NodeAbstractResourceInstance) apply(
 			PlannedPrivate: change.Private,
 			ProviderMeta:   metaConfigVal,
 		})
+
+		if limiter != nil {
+			limiter.Release(n.ResolvedProvider.Provider)
+		}
 	}
 	applyDiags := resp.Diagnostics
 	if applyConfig != nil {
@@ -2794,9 +3080,9 @@ func getAction(addr addrs.AbsResourceInstance, priorVal, plannedNewVal cty.Value
 	case eq && !matchedForceReplace:
 		action = plans.NoOp
 	case matchedForceReplace || !reqRep.Empty():
-		// If the user "forced replace" of this instance of if there are any
-		// "requires replace" paths left _after our filtering above_ then this
-		// is a replace action.
+		// If the user "forced replace" of this instance, or if there are any
+		// "requires replace" paths left _after our filtering above_, then
+		// this is a replace action.
 		if createBeforeDestroy {
 			action = plans.CreateThenDelete
 		} else {
@@ -2836,6 +3122,16 @@ func getRequiredReplaces(priorVal, plannedNewVal cty.Value, requiredReplaces []c
 
 	reqRep := cty.NewPathSet()
 	if len(requiredReplaces) > 0 {
+		// The provider returns these paths in its own order, which can vary
+		// from one call to the next even when nothing meaningful has
+		// changed. Sort them first so that any diagnostics we generate below
+		// have a deterministic order, regardless of what order the provider
+		// produced them in.
+		requiredReplaces = append([]cty.Path(nil), requiredReplaces...)
+		sort.Slice(requiredReplaces, func(i, j int) bool {
+			return tfdiags.FormatCtyPath(requiredReplaces[i]) < tfdiags.FormatCtyPath(requiredReplaces[j])
+		})
+
 		for _, path := range requiredReplaces {
 			if priorVal.IsNull() {
 				// If prior is null then we don't expect any RequiresReplace at all,
@@ -5,13 +5,17 @@ package terraform
 
 import (
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/zclconf/go-cty/cty"
 
 	"github.com/hashicorp/terraform/internal/addrs"
+	"github.com/hashicorp/terraform/internal/checks"
 	"github.com/hashicorp/terraform/internal/configs"
 	"github.com/hashicorp/terraform/internal/configs/configschema"
+	"github.com/hashicorp/terraform/internal/plans"
 	"github.com/hashicorp/terraform/internal/plans/deferring"
 	"github.com/hashicorp/terraform/internal/providers"
 	"github.com/hashicorp/terraform/internal/states"
@@ -188,6 +192,73 @@ aws_instance.foo:
 	`)
 }
 
+func TestNodeAbstractResourceInstance_WriteResourceInstanceState_providerNotInitialized(t *testing.T) {
+	state := states.NewState()
+	ctx := new(MockEvalContext)
+	ctx.StateState = state.SyncWrapper()
+	ctx.Scope = evalContextModuleInstance{Addr: addrs.RootModuleInstance}
+
+	obj := &states.ResourceInstanceObject{
+		Value: cty.ObjectVal(map[string]cty.Value{
+			"id": cty.StringVal("i-abc123"),
+		}),
+		Status: states.ObjectReady,
+	}
+
+	node := &NodeAbstractResourceInstance{
+		Addr: mustResourceInstanceAddr("aws_instance.foo"),
+		NodeAbstractResource: NodeAbstractResource{
+			ResolvedProvider: mustProviderConfig(`provider["registry.terraform.io/hashicorp/aws"]`),
+		},
+	}
+
+	// Deliberately leave ctx.ProviderProvider unset, so getProvider fails
+	// with "provider not initialized".
+	err := node.writeResourceInstanceState(ctx, obj, workingState)
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	got := err.Error()
+	if !strings.Contains(got, node.Addr.String()) {
+		t.Fatalf("expected error to name the resource address %s, got: %s", node.Addr, got)
+	}
+	if !strings.Contains(got, node.ResolvedProvider.String()) {
+		t.Fatalf("expected error to name the resolved provider %s, got: %s", node.ResolvedProvider, got)
+	}
+}
+
+func TestGetRequiredReplaces_deterministicOrder(t *testing.T) {
+	priorVal := cty.ObjectVal(map[string]cty.Value{
+		"id": cty.StringVal("foo"),
+	})
+	plannedNewVal := cty.ObjectVal(map[string]cty.Value{
+		"id": cty.StringVal("bar"),
+	})
+
+	// Neither of these attribute paths exists in the object, so both will
+	// fail to apply against both priorVal and plannedNewVal, producing an
+	// error diagnostic for each. We list them here in one order and the
+	// reverse order below, and expect the same diagnostic order back both
+	// times regardless of the order the provider returned them in.
+	pathA := cty.GetAttrPath("aaa")
+	pathB := cty.GetAttrPath("bbb")
+
+	_, diagsForward := getRequiredReplaces(priorVal, plannedNewVal, []cty.Path{pathA, pathB}, addrs.NewDefaultProvider("test"), mustResourceInstanceAddr("test_instance.foo"))
+	_, diagsReverse := getRequiredReplaces(priorVal, plannedNewVal, []cty.Path{pathB, pathA}, addrs.NewDefaultProvider("test"), mustResourceInstanceAddr("test_instance.foo"))
+
+	if len(diagsForward) != 2 || len(diagsReverse) != 2 {
+		t.Fatalf("expected 2 diagnostics each, got %d and %d", len(diagsForward), len(diagsReverse))
+	}
+
+	for i := range diagsForward {
+		got, want := diagsReverse[i].Description().Detail, diagsForward[i].Description().Detail
+		if got != want {
+			t.Fatalf("diagnostic order was not deterministic:\nforward[%d]: %s\nreverse[%d]: %s", i, want, i, got)
+		}
+	}
+}
+
 func TestNodeAbstractResourceInstance_refresh_with_deferred_read(t *testing.T) {
 	state := states.NewState()
 	evalCtx := &MockEvalContext{}
@@ -250,3 +321,378 @@ func TestNodeAbstractResourceInstance_refresh_with_deferred_read(t *testing.T) {
 		t.Fatalf("expected deferral to be AbsentPrereq, got %s", deferred.Reason)
 	}
 }
+
+func TestNodeAbstractResourceInstance_refresh_timeout(t *testing.T) {
+	state := states.NewState()
+	evalCtx := &MockEvalContext{}
+	evalCtx.StateState = state.SyncWrapper()
+	evalCtx.Scope = evalContextModuleInstance{Addr: addrs.RootModuleInstance}
+	evalCtx.RefreshTimeoutValue = 10 * time.Millisecond
+
+	mockProvider := mockProviderWithResourceTypeSchema("aws_instance", &configschema.Block{
+		Attributes: map[string]*configschema.Attribute{
+			"id": {
+				Type:     cty.String,
+				Optional: true,
+			},
+		},
+	})
+	mockProvider.ConfigureProviderCalled = true
+
+	mockProvider.ReadResourceFn = func(providers.ReadResourceRequest) providers.ReadResourceResponse {
+		time.Sleep(100 * time.Millisecond)
+		return providers.ReadResourceResponse{
+			NewState: cty.ObjectVal(map[string]cty.Value{
+				"id": cty.StringVal("i-abc123"),
+			}),
+		}
+	}
+
+	obj := &states.ResourceInstanceObject{
+		Value: cty.ObjectVal(map[string]cty.Value{
+			"id": cty.StringVal("i-abc123"),
+		}),
+		Status: states.ObjectReady,
+	}
+
+	node := &NodeAbstractResourceInstance{
+		Addr: mustResourceInstanceAddr("aws_instance.foo"),
+		NodeAbstractResource: NodeAbstractResource{
+			ResolvedProvider: mustProviderConfig(`provider["registry.terraform.io/hashicorp/aws"]`),
+		},
+	}
+	evalCtx.ProviderProvider = mockProvider
+	evalCtx.ProviderSchemaSchema = mockProvider.GetProviderSchema()
+	evalCtx.DeferralsState = deferring.NewDeferred(true)
+
+	_, _, diags := node.refresh(evalCtx, states.NotDeposed, obj, true)
+	if !diags.HasErrors() {
+		t.Fatal("expected a timeout diagnostic, got none")
+	}
+
+	if got, want := diags.Err().Error(), "Timeout while refreshing resource"; !strings.Contains(got, want) {
+		t.Fatalf("expected diagnostic to contain %q, got: %s", want, got)
+	}
+}
+
+func TestNodeAbstractResourceInstance_DiffPreview(t *testing.T) {
+	mockProvider := mockProviderWithResourceTypeSchema("aws_instance", &configschema.Block{
+		Attributes: map[string]*configschema.Attribute{
+			"id": {
+				Type:     cty.String,
+				Computed: true,
+			},
+			"ami": {
+				Type:     cty.String,
+				Required: true,
+			},
+		},
+	})
+	mockProvider.ConfigureProviderCalled = true
+
+	rc := &configs.Resource{
+		Mode: addrs.ManagedResourceMode,
+		Type: "aws_instance",
+		Name: "foo",
+		Config: configs.SynthBody("", map[string]cty.Value{
+			"ami": cty.StringVal("ami-1234"),
+		}),
+	}
+
+	changes := plans.NewChanges()
+
+	node := &NodeAbstractResourceInstance{
+		Addr: mustResourceInstanceAddr("aws_instance.foo"),
+		NodeAbstractResource: NodeAbstractResource{
+			Addr:             mustConfigResourceAddr("aws_instance.foo"),
+			Config:           rc,
+			ResolvedProvider: mustProviderConfig(`provider["registry.terraform.io/hashicorp/aws"]`),
+		},
+	}
+
+	ctx := &MockEvalContext{}
+	ctx.installSimpleEval()
+	ctx.ProviderProvider = mockProvider
+	ctx.ProviderSchemaSchema = mockProvider.GetProviderSchema()
+	ctx.DeferralsState = deferring.NewDeferred(false)
+	ctx.ChecksState = checks.NewState(nil)
+	ctx.ChangesChanges = changes.SyncWrapper()
+	hook := new(MockHook)
+	ctx.HookHook = hook
+
+	change, diags := node.diffPreview(ctx, nil, false, nil)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Err())
+	}
+	if change == nil {
+		t.Fatal("expected a non-nil change")
+	}
+	if change.Action != plans.Create {
+		t.Fatalf("expected a Create action, got %s", change.Action)
+	}
+
+	if hook.PreDiffCalled || hook.PostDiffCalled {
+		t.Fatal("expected no hooks to be called during a diff preview")
+	}
+	if len(changes.Resources) != 0 {
+		t.Fatal("expected the preview not to append to the plan's changeset")
+	}
+}
+
+// testPlanCache is a minimal in-memory ResourceInstancePlanCache for tests.
+// ResourceInstancePlanCacheKey isn't itself comparable, since it embeds an
+// addrs.AbsResourceInstance, so entries are indexed by its string form.
+type testPlanCache struct {
+	entries map[string]cty.Value
+}
+
+func newTestPlanCache() *testPlanCache {
+	return &testPlanCache{entries: make(map[string]cty.Value)}
+}
+
+func (c *testPlanCache) keyString(key ResourceInstancePlanCacheKey) string {
+	return fmt.Sprintf("%s %x %x", key.Addr, key.PriorHash, key.ConfigHash)
+}
+
+func (c *testPlanCache) GetNoOpPlan(key ResourceInstancePlanCacheKey) (cty.Value, bool) {
+	v, ok := c.entries[c.keyString(key)]
+	return v, ok
+}
+
+func (c *testPlanCache) PutNoOpPlan(key ResourceInstancePlanCacheKey, newVal cty.Value) {
+	c.entries[c.keyString(key)] = newVal
+}
+
+func TestNodeAbstractResourceInstance_plan_cacheHitAvoidsProviderCall(t *testing.T) {
+	mockProvider := mockProviderWithResourceTypeSchema("aws_instance", &configschema.Block{
+		Attributes: map[string]*configschema.Attribute{
+			"id": {
+				Type:     cty.String,
+				Computed: true,
+			},
+			"ami": {
+				Type:     cty.String,
+				Required: true,
+			},
+		},
+	})
+	mockProvider.ConfigureProviderCalled = true
+	mockProvider.GetProviderSchemaResponse.ServerCapabilities.DeterministicPlanning = true
+
+	var planResourceChangeCalls int
+	mockProvider.PlanResourceChangeFn = func(req providers.PlanResourceChangeRequest) providers.PlanResourceChangeResponse {
+		planResourceChangeCalls++
+		return providers.PlanResourceChangeResponse{
+			PlannedState: req.ProposedNewState,
+		}
+	}
+
+	rc := &configs.Resource{
+		Mode: addrs.ManagedResourceMode,
+		Type: "aws_instance",
+		Name: "foo",
+		Config: configs.SynthBody("", map[string]cty.Value{
+			"ami": cty.StringVal("ami-1234"),
+		}),
+		Managed: &configs.ManagedResource{},
+	}
+
+	currentState := &states.ResourceInstanceObject{
+		Value: cty.ObjectVal(map[string]cty.Value{
+			"id":  cty.StringVal("i-abc123"),
+			"ami": cty.StringVal("ami-1234"),
+		}),
+		Status: states.ObjectReady,
+	}
+
+	newNode := func() *NodeAbstractResourceInstance {
+		return &NodeAbstractResourceInstance{
+			Addr: mustResourceInstanceAddr("aws_instance.foo"),
+			NodeAbstractResource: NodeAbstractResource{
+				Addr:             mustConfigResourceAddr("aws_instance.foo"),
+				Config:           rc,
+				ResolvedProvider: mustProviderConfig(`provider["registry.terraform.io/hashicorp/aws"]`),
+			},
+		}
+	}
+
+	newCtx := func(cache ResourceInstancePlanCache) *MockEvalContext {
+		ctx := &MockEvalContext{}
+		ctx.installSimpleEval()
+		ctx.ProviderProvider = mockProvider
+		ctx.ProviderSchemaSchema = mockProvider.GetProviderSchema()
+		ctx.DeferralsState = deferring.NewDeferred(false)
+		ctx.ChecksState = checks.NewState(nil)
+		ctx.ChangesChanges = plans.NewChanges().SyncWrapper()
+		ctx.HookHook = new(MockHook)
+		ctx.PlanCacheValue = cache
+		return ctx
+	}
+
+	cache := newTestPlanCache()
+
+	change, diags := newNode().diffPreview(newCtx(cache), currentState, false, nil)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Err())
+	}
+	if change.Action != plans.NoOp {
+		t.Fatalf("expected a NoOp action, got %s", change.Action)
+	}
+	if planResourceChangeCalls != 1 {
+		t.Fatalf("expected 1 call to PlanResourceChange, got %d", planResourceChangeCalls)
+	}
+
+	// A second plan with the same prior state and configuration should be
+	// served entirely from the cache, without calling the provider again.
+	change, diags = newNode().diffPreview(newCtx(cache), currentState, false, nil)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Err())
+	}
+	if change.Action != plans.NoOp {
+		t.Fatalf("expected a NoOp action, got %s", change.Action)
+	}
+	if planResourceChangeCalls != 1 {
+		t.Fatalf("expected still 1 call to PlanResourceChange after a cache hit, got %d", planResourceChangeCalls)
+	}
+}
+
+func TestNodeAbstractResourceInstance_plan_cacheSkippedForUnknownConfig(t *testing.T) {
+	mockProvider := mockProviderWithResourceTypeSchema("aws_instance", &configschema.Block{
+		Attributes: map[string]*configschema.Attribute{
+			"id": {
+				Type:     cty.String,
+				Computed: true,
+			},
+			"ami": {
+				Type:     cty.String,
+				Required: true,
+			},
+		},
+	})
+	mockProvider.ConfigureProviderCalled = true
+	mockProvider.GetProviderSchemaResponse.ServerCapabilities.DeterministicPlanning = true
+
+	mockProvider.PlanResourceChangeFn = func(req providers.PlanResourceChangeRequest) providers.PlanResourceChangeResponse {
+		return providers.PlanResourceChangeResponse{
+			PlannedState: req.ProposedNewState,
+		}
+	}
+
+	rc := &configs.Resource{
+		Mode: addrs.ManagedResourceMode,
+		Type: "aws_instance",
+		Name: "foo",
+		Config: configs.SynthBody("", map[string]cty.Value{
+			"ami": cty.UnknownVal(cty.String),
+		}),
+		Managed: &configs.ManagedResource{},
+	}
+
+	currentState := &states.ResourceInstanceObject{
+		Value: cty.ObjectVal(map[string]cty.Value{
+			"id":  cty.StringVal("i-abc123"),
+			"ami": cty.StringVal("ami-1234"),
+		}),
+		Status: states.ObjectReady,
+	}
+
+	node := &NodeAbstractResourceInstance{
+		Addr: mustResourceInstanceAddr("aws_instance.foo"),
+		NodeAbstractResource: NodeAbstractResource{
+			Addr:             mustConfigResourceAddr("aws_instance.foo"),
+			Config:           rc,
+			ResolvedProvider: mustProviderConfig(`provider["registry.terraform.io/hashicorp/aws"]`),
+		},
+	}
+
+	ctx := &MockEvalContext{}
+	ctx.installSimpleEval()
+	ctx.ProviderProvider = mockProvider
+	ctx.ProviderSchemaSchema = mockProvider.GetProviderSchema()
+	ctx.DeferralsState = deferring.NewDeferred(false)
+	ctx.ChecksState = checks.NewState(nil)
+	ctx.ChangesChanges = plans.NewChanges().SyncWrapper()
+	ctx.HookHook = new(MockHook)
+	ctx.PlanCacheValue = newTestPlanCache()
+
+	// This must not panic: a config value containing an unknown can't be
+	// hashed for the plan cache, so the cache should simply be bypassed.
+	_, diags := node.diffPreview(ctx, currentState, false, nil)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Err())
+	}
+}
+
+func TestNodeAbstractResourceInstance_PruneStaleDependencies(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+resource "aws_instance" "foo" {
+}
+`,
+	})
+
+	node := &NodeAbstractResourceInstance{
+		NodeAbstractResource: NodeAbstractResource{
+			Addr: mustConfigResourceAddr("aws_instance.foo"),
+		},
+	}
+
+	deps := []addrs.ConfigResource{
+		mustConfigResourceAddr("aws_instance.foo"),
+		mustConfigResourceAddr("aws_instance.removed"),
+	}
+
+	got := node.pruneStaleDependencies(m, nil, deps)
+	want := mustConfigResourceAddr("aws_instance.foo")
+
+	if len(got) != 1 || !got[0].Equal(want) {
+		t.Fatalf("wrong result\ngot:  %#v\nwant: [%#v]", got, want)
+	}
+}
+
+func TestNodeAbstractResourceInstance_PruneStaleDependencies_orphanedInState(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+resource "aws_instance" "foo" {
+}
+`,
+	})
+
+	state := states.NewState()
+	root := state.EnsureModule(addrs.RootModuleInstance)
+	root.SetResourceInstanceCurrent(
+		mustResourceInstanceAddr("aws_instance.removed").Resource,
+		&states.ResourceInstanceObjectSrc{
+			Status:    states.ObjectReady,
+			AttrsJSON: []byte(`{"id":"removed"}`),
+		},
+		mustProviderConfig(`provider["registry.terraform.io/hashicorp/aws"]`),
+	)
+
+	node := &NodeAbstractResourceInstance{
+		NodeAbstractResource: NodeAbstractResource{
+			Addr: mustConfigResourceAddr("aws_instance.foo"),
+		},
+	}
+
+	deps := []addrs.ConfigResource{
+		mustConfigResourceAddr("aws_instance.foo"),
+		mustConfigResourceAddr("aws_instance.removed"),
+		mustConfigResourceAddr("aws_instance.gone"),
+	}
+
+	got := node.pruneStaleDependencies(m, state.SyncWrapper(), deps)
+	want := []addrs.ConfigResource{
+		mustConfigResourceAddr("aws_instance.foo"),
+		mustConfigResourceAddr("aws_instance.removed"),
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("wrong result\ngot:  %#v\nwant: %#v", got, want)
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Fatalf("wrong result\ngot:  %#v\nwant: %#v", got, want)
+		}
+	}
+}
@@ -308,7 +308,13 @@ func (n *NodePlannableResourceInstance) managedResourceExecute(ctx EvalContext)
 			// stored to state once the changes are applied. If the plan
 			// results in no changes, we will re-write these dependencies
 			// below.
-			instanceRefreshState.Dependencies = mergeDeps(n.Dependencies, instanceRefreshState.Dependencies)
+			//
+			// A dependency address absent from the current configuration may
+			// still be a resource orphaned for destruction this round, so we
+			// only prune addresses that are gone from both the configuration
+			// and the state; pruneStaleDependencies preserves those.
+			merged := mergeDeps(n.Dependencies, instanceRefreshState.Dependencies)
+			instanceRefreshState.Dependencies = n.pruneStaleDependencies(ctx.Config(), ctx.RefreshState(), merged)
 		}
 
 		if deferred == nil && refreshDeferred != nil {
@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package terraform
+
+import (
+	"crypto/sha256"
+
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
+	"github.com/hashicorp/terraform/internal/addrs"
+)
+
+// ResourceInstancePlanCache is an optional, opt-in cache that lets
+// NodeAbstractResourceInstance.plan reuse the result of an earlier no-op
+// plan instead of calling the provider's PlanResourceChange again, when a
+// resource instance's prior state and configuration are unchanged from
+// that earlier plan. It exists as an interface, rather than a concrete
+// type, so that callers control the cache's lifetime and size limits.
+//
+// Terraform only consults the cache for providers that advertise the
+// DeterministicPlanning server capability, since a cache hit skips the
+// PlanResourceChange call entirely and so can only be trusted for
+// providers that promise their plan for a given prior state and
+// configuration is always the same.
+type ResourceInstancePlanCache interface {
+	// GetNoOpPlan returns the planned new value to reuse for a no-op
+	// change matching the given key, and true, if the cache has one.
+	// The second return value is false if there is no cached entry.
+	GetNoOpPlan(key ResourceInstancePlanCacheKey) (cty.Value, bool)
+
+	// PutNoOpPlan records that planning the resource instance identified
+	// by key produced a no-op change, so that a later call with an equal
+	// key can be served from the cache instead of calling the provider.
+	PutNoOpPlan(key ResourceInstancePlanCacheKey, newVal cty.Value)
+}
+
+// ResourceInstancePlanCacheKey identifies a cacheable planning result: a
+// resource instance together with hashes of the prior state value and the
+// configuration value that produced it. Two plans with equal keys are only
+// guaranteed to produce the same result for a provider that advertises the
+// DeterministicPlanning server capability.
+type ResourceInstancePlanCacheKey struct {
+	Addr       addrs.AbsResourceInstance
+	PriorHash  [sha256.Size]byte
+	ConfigHash [sha256.Size]byte
+}
+
+// hashPlanCacheValue produces a stable hash of a cty.Value for use in a
+// ResourceInstancePlanCacheKey. It relies on cty's JSON encoding, which
+// captures both the value and its type, so that two values hash equal only
+// if both their shape and content match.
+//
+// The second return value is false if v can't be encoded, which callers
+// should treat as "not cacheable" rather than an error: ctyjson.Marshal
+// rejects any value containing an unknown, which is routine for a
+// resource instance whose configuration still has an unresolved reference
+// to another resource's not-yet-known computed output.
+func hashPlanCacheValue(v cty.Value) ([sha256.Size]byte, bool) {
+	encoded, err := ctyjson.Marshal(v, v.Type())
+	if err != nil {
+		return [sha256.Size]byte{}, false
+	}
+	return sha256.Sum256(encoded), true
+}
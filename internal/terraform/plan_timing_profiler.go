@@ -0,0 +1,24 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package terraform
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform/internal/addrs"
+)
+
+// PlanTimingProfiler is an optional hook for recording how long each
+// resource instance's PlanResourceChange call took. It exists separately
+// from the Hook interface because it's a performance-debugging aid rather
+// than a user-facing notification, and because it must impose no overhead
+// at all when no profiler is configured.
+type PlanTimingProfiler interface {
+	// RecordPlanDuration is called once per resource instance plan that
+	// calls the provider's PlanResourceChange, with the time spent in that
+	// call. It isn't called when the plan is served from a
+	// ResourceInstancePlanCache or from a test override, and the second
+	// PlanResourceChange call made when planning a replacement isn't timed.
+	RecordPlanDuration(addr addrs.AbsResourceInstance, d time.Duration)
+}
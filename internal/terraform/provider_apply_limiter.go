@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package terraform
+
+import (
+	"sync"
+
+	"github.com/hashicorp/terraform/internal/addrs"
+)
+
+// ProviderApplyLimiter caps how many ApplyResourceChange calls are in
+// flight for a single provider at once; all configurations (including
+// aliases) of the same provider share one cap. It complements the
+// graph-wide -parallelism limit, which bounds the total number of nodes
+// executing concurrently but says nothing about how much of that
+// concurrency lands on any one provider, which can overwhelm a provider's
+// own backend API.
+//
+// A nil *ProviderApplyLimiter imposes no limit, which is the default.
+type ProviderApplyLimiter struct {
+	max int
+
+	mu   sync.Mutex
+	sems map[string]Semaphore
+}
+
+// NewProviderApplyLimiter returns a ProviderApplyLimiter that allows at most
+// max concurrent ApplyResourceChange calls per provider.
+func NewProviderApplyLimiter(max int) *ProviderApplyLimiter {
+	return &ProviderApplyLimiter{
+		max:  max,
+		sems: make(map[string]Semaphore),
+	}
+}
+
+// Acquire blocks until a slot is available for the given provider.
+func (l *ProviderApplyLimiter) Acquire(provider addrs.Provider) {
+	l.semaphoreFor(provider).Acquire()
+}
+
+// Release returns a slot previously obtained from Acquire for the given
+// provider.
+func (l *ProviderApplyLimiter) Release(provider addrs.Provider) {
+	l.semaphoreFor(provider).Release()
+}
+
+func (l *ProviderApplyLimiter) semaphoreFor(provider addrs.Provider) Semaphore {
+	key := provider.String()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	sem, ok := l.sems[key]
+	if !ok {
+		sem = NewSemaphore(l.max)
+		l.sems[key] = sem
+	}
+	return sem
+}
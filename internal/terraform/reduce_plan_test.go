@@ -9,6 +9,8 @@ import (
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hashicorp/terraform/internal/configs/configschema"
 )
 
 func TestProcessIgnoreChangesIndividual(t *testing.T) {
@@ -444,3 +446,85 @@ func TestProcessIgnoreChangesIndividual(t *testing.T) {
 		})
 	}
 }
+
+func TestWarnForIgnoreChangesOnRequiredAttrs(t *testing.T) {
+	schema := &configschema.Block{
+		Attributes: map[string]*configschema.Attribute{
+			"required_string": {Type: cty.String, Required: true},
+			"optional_string": {Type: cty.String, Optional: true},
+			"computed_string": {Type: cty.String, Computed: true},
+		},
+	}
+
+	tests := map[string]struct {
+		Old, New cty.Value
+		Ignore   []string
+		WantWarn bool
+	}{
+		"required attribute changed": {
+			cty.ObjectVal(map[string]cty.Value{
+				"required_string": cty.StringVal("old"),
+				"optional_string": cty.StringVal("old"),
+				"computed_string": cty.StringVal("old"),
+			}),
+			cty.ObjectVal(map[string]cty.Value{
+				"required_string": cty.StringVal("new"),
+				"optional_string": cty.StringVal("old"),
+				"computed_string": cty.StringVal("old"),
+			}),
+			[]string{"required_string"},
+			true,
+		},
+		"required attribute unchanged": {
+			cty.ObjectVal(map[string]cty.Value{
+				"required_string": cty.StringVal("same"),
+				"optional_string": cty.StringVal("old"),
+				"computed_string": cty.StringVal("old"),
+			}),
+			cty.ObjectVal(map[string]cty.Value{
+				"required_string": cty.StringVal("same"),
+				"optional_string": cty.StringVal("old"),
+				"computed_string": cty.StringVal("old"),
+			}),
+			[]string{"required_string"},
+			false,
+		},
+		"optional attribute changed": {
+			cty.ObjectVal(map[string]cty.Value{
+				"required_string": cty.StringVal("same"),
+				"optional_string": cty.StringVal("old"),
+				"computed_string": cty.StringVal("old"),
+			}),
+			cty.ObjectVal(map[string]cty.Value{
+				"required_string": cty.StringVal("same"),
+				"optional_string": cty.StringVal("new"),
+				"computed_string": cty.StringVal("old"),
+			}),
+			[]string{"optional_string"},
+			false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ignore := make([]hcl.Traversal, len(test.Ignore))
+			for i, ignoreStr := range test.Ignore {
+				trav, diags := hclsyntax.ParseTraversalAbs([]byte(ignoreStr), "", hcl.Pos{Line: 1, Column: 1})
+				if diags.HasErrors() {
+					t.Fatalf("failed to parse %q: %s", ignoreStr, diags.Error())
+				}
+				ignore[i] = trav
+			}
+
+			diags := warnForIgnoreChangesOnRequiredAttrs(test.Old, test.New, traversalsToPaths(ignore), schema)
+			if diags.HasErrors() {
+				t.Fatal(diags.Err())
+			}
+
+			gotWarn := len(diags) > 0
+			if gotWarn != test.WantWarn {
+				t.Errorf("wrong warning result\ngot:  %v\nwant: %v\ndiags: %s", gotWarn, test.WantWarn, diags.Err())
+			}
+		})
+	}
+}